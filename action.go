@@ -2,9 +2,14 @@ package sagas
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 )
 
+// ErrActionPanicked is the error returned by an action whose function panicked. It wraps the recovered value.
+var ErrActionPanicked = errors.New("action panicked")
+
 // Action is an interface that contains a method that receives a context and returns an error.
 // It is an abstraction of the action that will be executed by a Step.
 type Action interface {
@@ -42,13 +47,25 @@ func NewAction(actionFn ActionFn) Action {
 }
 
 // run is the method that executes the actionFn. Is is private and is used by the Step struct.
-func (a action) run(ctx context.Context) error {
+// A panic of the actionFn is recovered, logged with the logger carried by the context and
+// returned as an error that wraps ErrActionPanicked, so the attempt fails.
+func (a action) run(ctx context.Context) (err error) {
 
 	defer func() {
 		if recoverErr := recover(); recoverErr != nil {
-			log.Println("recovering from panic: ", recoverErr)
+			logContext(ctx, slog.LevelError, "recovering from panic", slog.Any("panic", recoverErr))
+			err = fmt.Errorf("%w: %v", ErrActionPanicked, recoverErr)
 		}
 	}()
 
 	return a.actionFn(ctx)
 }
+
+// actionFnOf returns the function run by the action, so it can be wrapped by middlewares inside the recovery
+// of the action.
+func actionFnOf(a Action) ActionFn {
+	if a, ok := a.(*action); ok {
+		return a.actionFn
+	}
+	return a.run
+}
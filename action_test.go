@@ -41,13 +41,13 @@ func Test_action_run(t *testing.T) {
 		},
 
 		{
-			name: "[SUCCESSa] actionFn panics",
+			name: "[FAILURE] actionFn panics",
 			args: args{
 				fn: func(ctx context.Context) error {
 					panic("panic")
 				},
 			},
-			expectedError: "",
+			expectedError: "action panicked: panic",
 		},
 	}
 
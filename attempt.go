@@ -0,0 +1,112 @@
+package sagas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Attempt is a struct that represents a single execution of the action of a Step. It is kept in the attempt
+// history of the Step and carried by the Retrying notifications.
+type Attempt struct {
+	// Number is the number of the attempt, starting at 1.
+	Number int
+	// StartedAt is the time the attempt started.
+	StartedAt time.Time
+	// EndedAt is the time the attempt ended.
+	EndedAt time.Time
+	// Err is the error returned by the action in the attempt, if any.
+	Err error
+	// Status is the status given by the classifier to the error of the attempt.
+	Status Status
+	// NextDelay is the amount of time waited before the next attempt. It is zero for the last attempt.
+	NextDelay time.Duration
+}
+
+// Duration returns the amount of time the attempt took.
+func (a Attempt) Duration() time.Duration {
+	return a.EndedAt.Sub(a.StartedAt)
+}
+
+// jsonAttempt is the JSON representation of an Attempt. The error is kept as its message.
+type jsonAttempt struct {
+	Number    int           `json:"number"`
+	StartedAt time.Time     `json:"started_at"`
+	EndedAt   time.Time     `json:"ended_at"`
+	Error     string        `json:"error,omitempty"`
+	Status    Status        `json:"status"`
+	NextDelay time.Duration `json:"next_delay"`
+}
+
+// MarshalJSON returns the JSON representation of the attempt. The error is serialized as its message.
+func (a Attempt) MarshalJSON() ([]byte, error) {
+	wire := jsonAttempt{
+		Number:    a.Number,
+		StartedAt: a.StartedAt,
+		EndedAt:   a.EndedAt,
+		Status:    a.Status,
+		NextDelay: a.NextDelay,
+	}
+	if a.Err != nil {
+		wire.Error = a.Err.Error()
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON parses the JSON representation of an attempt. The error is parsed as a plain error with the
+// serialized message.
+func (a *Attempt) UnmarshalJSON(data []byte) error {
+	wire := jsonAttempt{}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	*a = Attempt{
+		Number:    wire.Number,
+		StartedAt: wire.StartedAt,
+		EndedAt:   wire.EndedAt,
+		Status:    wire.Status,
+		NextDelay: wire.NextDelay,
+	}
+	if wire.Error != "" {
+		a.Err = errors.New(wire.Error)
+	}
+	return nil
+}
+
+// attemptRecorder is the interface implemented by the Step to be told about the attempts performed by a
+// Retrier. It travels through the context given to the Retrier, so that a Retrier can be shared by many Steps.
+type attemptRecorder interface {
+	// recordAttempt records the given attempt. If the attempt will be retried, it notifies Retrying.
+	recordAttempt(ctx context.Context, attempt Attempt, retrying bool)
+}
+
+// attemptRecorderKey is the context key of the attemptRecorder.
+type attemptRecorderKey struct{}
+
+// withAttemptRecorder returns a copy of the context that carries the given attemptRecorder.
+func withAttemptRecorder(ctx context.Context, recorder attemptRecorder) context.Context {
+	return context.WithValue(ctx, attemptRecorderKey{}, recorder)
+}
+
+// recordAttempt records the given attempt in the attemptRecorder carried by the context, if any.
+func recordAttempt(ctx context.Context, attempt Attempt, retrying bool) {
+	if recorder, ok := ctx.Value(attemptRecorderKey{}).(attemptRecorder); ok {
+		recorder.recordAttempt(ctx, attempt, retrying)
+	}
+}
+
+// attemptNumberKey is the context key of the number of the running attempt.
+type attemptNumberKey struct{}
+
+// withAttemptNumber returns a copy of the context that carries the number of the running attempt.
+func withAttemptNumber(ctx context.Context, number int) context.Context {
+	return context.WithValue(ctx, attemptNumberKey{}, number)
+}
+
+// attemptNumberFromContext returns the number of the running attempt carried by the context, if any.
+func attemptNumberFromContext(ctx context.Context) (int, bool) {
+	number, ok := ctx.Value(attemptNumberKey{}).(int)
+	return number, ok
+}
@@ -0,0 +1,193 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type observerFunc func(context.Context, Notification)
+
+func (f observerFunc) Execute(ctx context.Context, notification Notification) {
+	f(ctx, notification)
+}
+
+func (f observerFunc) getExecutionPlan() ExecutionPlan {
+	return nil
+}
+
+type attemptRecorderFunc func(context.Context, Attempt, bool)
+
+func (f attemptRecorderFunc) recordAttempt(ctx context.Context, attempt Attempt, retrying bool) {
+	f(ctx, attempt, retrying)
+}
+
+func Test_Attempt_Duration(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+
+	tests := []struct {
+		name    string
+		attempt Attempt
+		want    time.Duration
+	}{
+		{
+			name: "[SUCCESS] Should return the time between the start and the end of the attempt",
+			attempt: Attempt{
+				StartedAt: start,
+				EndedAt:   start.Add(2 * time.Second),
+			},
+			want: 2 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				assert.Equal(t, test.want, test.attempt.Duration())
+			})
+		})
+	}
+}
+
+func Test_recordAttempt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		withRecorder bool
+		want         []Attempt
+	}{
+		{
+			name:         "[SUCCESS] Should record the attempt in the recorder of the context",
+			withRecorder: true,
+			want:         []Attempt{{Number: 1}},
+		},
+
+		{
+			name:         "[SUCCESS] Should do nothing if the context has no recorder",
+			withRecorder: false,
+			want:         nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				var got []Attempt
+				ctx := context.Background()
+				if test.withRecorder {
+					ctx = withAttemptRecorder(ctx, attemptRecorderFunc(func(_ context.Context, a Attempt, _ bool) {
+						got = append(got, a)
+					}))
+				}
+				recordAttempt(ctx, Attempt{Number: 1}, false)
+				assert.Equal(t, test.want, got)
+			})
+		})
+	}
+}
+
+func Test_step_Attempts(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		failures int
+		retrier  Retrier
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantAttempts int
+		wantRetrying int
+		wantStatus   Status
+	}{
+		{
+			name: "[SUCCESS] Should record a single attempt without retrier",
+			args: args{
+				failures: 0,
+			},
+			wantAttempts: 1,
+			wantRetrying: 0,
+			wantStatus:   Successed,
+		},
+
+		{
+			name: "[SUCCESS] Should record every attempt and notify the retries",
+			args: args{
+				failures: 2,
+				retrier:  NewRetrier(BackoffConstant(3, 1*time.Millisecond)),
+			},
+			wantAttempts: 3,
+			wantRetrying: 2,
+			wantStatus:   Successed,
+		},
+
+		{
+			name: "[ERROR] Should not notify a retry after the last attempt",
+			args: args{
+				failures: 5,
+				retrier:  NewRetrier(BackoffConstant(1, 1*time.Millisecond)),
+			},
+			wantAttempts: 2,
+			wantRetrying: 1,
+			wantStatus:   Failed,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				calls := 0
+				options := []StepOption{}
+				if test.args.retrier != nil {
+					options = append(options, WithStepRetrier(test.args.retrier))
+				}
+				s := NewStep("test", func(context.Context) error {
+					calls++
+					if calls <= test.args.failures {
+						return assert.AnError
+					}
+					return nil
+				}, options...)
+
+				mutex := sync.Mutex{}
+				retrying := []Notification{}
+				s.getNotifier().Add(observerFunc(func(_ context.Context, n Notification) {
+					if n.Event == Retrying {
+						mutex.Lock()
+						retrying = append(retrying, n)
+						mutex.Unlock()
+					}
+				}))
+
+				_ = s.Run(context.Background())
+
+				attempts := s.Attempts()
+				assert.Len(t, attempts, test.wantAttempts)
+				assert.Len(t, retrying, test.wantRetrying)
+				assert.Equal(t, test.wantStatus, s.GetStatus())
+				for i, a := range attempts {
+					assert.Equal(t, i+1, a.Number)
+					assert.False(t, a.EndedAt.Before(a.StartedAt))
+				}
+				for i, n := range retrying {
+					assert.Equal(t, i+1, n.Attempt.Number)
+					assert.Equal(t, assert.AnError, n.Attempt.Err)
+					assert.Equal(t, Retry, n.Attempt.Status)
+				}
+			})
+		})
+	}
+}
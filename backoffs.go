@@ -1,6 +1,39 @@
 package sagas
 
-import "time"
+import (
+	"sync"
+	"time"
+)
+
+// Backoff is the interface that wraps the method to compute the delay before each retry. It is used by the
+// Retrier to express back-off strategies that a precomputed slice of delays can not, such as unlimited
+// retries or delays that depend on the previous ones.
+type Backoff interface {
+	// Next receives the number of retries already performed and the error of the last attempt, and returns
+	// the delay to wait before the next retry. If the returned boolean is false, no more retries are performed.
+	Next(attempt int, err error) (time.Duration, bool)
+}
+
+// BackoffFunc is a function type that implements the Backoff interface. Example:
+//
+//	backoff := sagas.BackoffFunc(func(attempt int, err error) (time.Duration, bool) {
+//		return 1 * time.Second, true
+//	})
+//
+// The above example creates a Backoff that retries forever, waiting 1 second between each retry.
+type BackoffFunc func(attempt int, err error) (time.Duration, bool)
+
+// Next calls the function itself.
+func (f BackoffFunc) Next(attempt int, err error) (time.Duration, bool) {
+	return f(attempt, err)
+}
+
+// backoffStarter is implemented by the back-off strategies that depend on randomness or keep state between
+// the retries of a single run. The Retrier calls start before each run and uses the returned Backoff.
+type backoffStarter interface {
+	// start returns a Backoff for a single run that draws its random numbers from the given random.
+	start(random *random) Backoff
+}
 
 // BackoffConstant receives the number of attempts and the amount of time to wait between each attempt and returns a slice
 // of time.Duration. It generates a simple back-off strategy of retrying 'attempts' times, and waiting 'amount' time after each one.
@@ -55,3 +88,189 @@ func BackoffLimitedExponential(attempts int, initialAmount time.Duration, limitA
 	}
 	return ret
 }
+
+// BackoffSlice receives a slice of time.Duration and returns a Backoff. It is the adapter of the slices generated
+// by BackoffConstant, BackoffExponential and BackoffLimitedExponential. The length of the slice indicates how many
+// times an action will be retried, and the value at each index indicates the amount of time waited before each.
+// Example:
+//
+//	backoff := sagas.BackoffSlice(sagas.BackoffConstant(3, 1*time.Second))
+//
+// The backoff will retry 3 times, waiting 1 second before each retry.
+func BackoffSlice(durations []time.Duration) Backoff {
+	return BackoffFunc(func(attempt int, _ error) (time.Duration, bool) {
+		if attempt < 0 || attempt >= len(durations) {
+			return 0, false
+		}
+		return durations[attempt], true
+	})
+}
+
+// BackoffFibonacci receives the number of attempts and the initial amount of time to wait and returns a Backoff.
+// It generates a back-off strategy of retrying 'attempts' times, waiting the Fibonacci sequence multiplied by
+// 'initialAmount' after each one. If 'attempts' is negative, it retries forever. Example:
+//
+//	backoff := sagas.BackoffFibonacci(5, 1*time.Second)
+//
+// The backoff will wait [1s, 1s, 2s, 3s, 5s] before each retry.
+func BackoffFibonacci(attempts int, initialAmount time.Duration) Backoff {
+	return BackoffFunc(func(attempt int, _ error) (time.Duration, bool) {
+		if !withinAttempts(attempt, attempts) {
+			return 0, false
+		}
+
+		previous, current := time.Duration(0), initialAmount
+		for i := 0; i < attempt; i++ {
+			if current > maxDuration-previous {
+				return maxDuration, true
+			}
+			previous, current = current, previous+current
+		}
+		return current, true
+	})
+}
+
+// BackoffFullJitter receives the number of attempts, the base amount of time to wait and the limit amount of time
+// to wait and returns a Backoff. It generates an exponential back-off strategy of retrying 'attempts' times, waiting
+// a random amount of time between zero and the exponential delay, which is limited to 'limitAmount'. If 'attempts'
+// is negative, it retries forever. Example:
+//
+//	backoff := sagas.BackoffFullJitter(5, 1*time.Second, 10*time.Second)
+//
+// The backoff will wait a random amount of time in the ranges [0s, 1s), [0s, 2s), [0s, 4s), [0s, 8s), [0s, 10s).
+func BackoffFullJitter(attempts int, baseAmount time.Duration, limitAmount time.Duration) Backoff {
+	return &jitteredBackoff{
+		attempts:    attempts,
+		baseAmount:  baseAmount,
+		limitAmount: limitAmount,
+		jitter:      JitterFull(),
+		random:      newTimeRandom(),
+	}
+}
+
+// BackoffEqualJitter receives the number of attempts, the base amount of time to wait and the limit amount of
+// time to wait and returns a Backoff. It generates an exponential back-off strategy of retrying 'attempts' times,
+// waiting half of the exponential delay plus a random amount of time up to the other half, the exponential delay
+// being limited to 'limitAmount'. If 'attempts' is negative, it retries forever. Example:
+//
+//	backoff := sagas.BackoffEqualJitter(5, 1*time.Second, 10*time.Second)
+//
+// The backoff will wait a random amount of time in the ranges [0.5s, 1s), [1s, 2s), [2s, 4s), [4s, 8s), [5s, 10s).
+func BackoffEqualJitter(attempts int, baseAmount time.Duration, limitAmount time.Duration) Backoff {
+	return &jitteredBackoff{
+		attempts:    attempts,
+		baseAmount:  baseAmount,
+		limitAmount: limitAmount,
+		jitter:      JitterEqual(),
+		random:      newTimeRandom(),
+	}
+}
+
+// BackoffDecorrelatedJitter receives the number of attempts, the base amount of time to wait and the limit amount
+// of time to wait and returns a Backoff. It generates a back-off strategy of retrying 'attempts' times, waiting a
+// random amount of time between 'baseAmount' and three times the previous delay, limited to 'limitAmount'. If
+// 'attempts' is negative, it retries forever. Example:
+//
+//	backoff := sagas.BackoffDecorrelatedJitter(5, 1*time.Second, 10*time.Second)
+//
+// The backoff will wait a random amount of time in the range [1s, 3s) before the first retry, and then in the
+// range [1s, 3*previous) limited to 10s before each subsequent retry.
+func BackoffDecorrelatedJitter(attempts int, baseAmount time.Duration, limitAmount time.Duration) Backoff {
+	return &decorrelatedBackoff{
+		attempts:    attempts,
+		baseAmount:  baseAmount,
+		limitAmount: limitAmount,
+		previous:    baseAmount,
+		random:      newTimeRandom(),
+	}
+}
+
+// maxDuration is the largest representable time.Duration.
+const maxDuration = time.Duration(1<<63 - 1)
+
+// withinAttempts reports whether the given attempt is allowed by the number of attempts. A negative number of
+// attempts allows any attempt.
+func withinAttempts(attempt int, attempts int) bool {
+	return attempt >= 0 && (attempts < 0 || attempt < attempts)
+}
+
+// exponential returns baseAmount doubled 'attempt' times, limited to limitAmount.
+func exponential(attempt int, baseAmount time.Duration, limitAmount time.Duration) time.Duration {
+	delay := baseAmount
+	for i := 0; i < attempt && delay < limitAmount; i++ {
+		if delay > maxDuration/2 {
+			return limitAmount
+		}
+		delay *= 2
+	}
+	return min(delay, limitAmount)
+}
+
+// jitteredBackoff is an exponential Backoff randomized by a Jitter.
+type jitteredBackoff struct {
+	attempts    int
+	baseAmount  time.Duration
+	limitAmount time.Duration
+	jitter      Jitter
+	random      *random
+}
+
+// Next returns the randomized exponential delay for the given attempt.
+func (b *jitteredBackoff) Next(attempt int, _ error) (time.Duration, bool) {
+	if !withinAttempts(attempt, b.attempts) {
+		return 0, false
+	}
+	return b.jitter(exponential(attempt, b.baseAmount, b.limitAmount), b.random.float64()), true
+}
+
+// start returns a copy of the Backoff that draws its random numbers from the given random.
+func (b *jitteredBackoff) start(random *random) Backoff {
+	run := *b
+	run.random = random
+	return &run
+}
+
+// decorrelatedBackoff is a Backoff whose delays depend on the previous delay.
+type decorrelatedBackoff struct {
+	attempts    int
+	baseAmount  time.Duration
+	limitAmount time.Duration
+	previous    time.Duration
+	random      *random
+	mutex       sync.Mutex
+}
+
+// Next returns a random delay between the base amount and three times the previous delay. The first attempt
+// resets the previous delay to the base amount.
+func (b *decorrelatedBackoff) Next(attempt int, _ error) (time.Duration, bool) {
+	if !withinAttempts(attempt, b.attempts) {
+		return 0, false
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if attempt == 0 {
+		b.previous = b.baseAmount
+	}
+
+	upper := b.previous * 3
+	if b.previous > maxDuration/3 {
+		upper = maxDuration
+	}
+
+	delay := b.baseAmount + time.Duration(b.random.float64()*float64(upper-b.baseAmount))
+	b.previous = min(delay, b.limitAmount)
+	return b.previous, true
+}
+
+// start returns a new Backoff for a single run, so that concurrent runs do not share the previous delay.
+func (b *decorrelatedBackoff) start(random *random) Backoff {
+	return &decorrelatedBackoff{
+		attempts:    b.attempts,
+		baseAmount:  b.baseAmount,
+		limitAmount: b.limitAmount,
+		previous:    b.baseAmount,
+		random:      random,
+	}
+}
@@ -168,3 +168,200 @@ func Test_LimitedExponentialBackoff(t *testing.T) {
 		})
 	}
 }
+
+func Test_BackoffSlice(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		durations []time.Duration
+		attempt   int
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		want   time.Duration
+		wantOk bool
+	}{
+		{
+			name: "[SUCCESS] Should return the duration of the attempt",
+			args: args{
+				durations: BackoffExponential(3, 1*time.Second, 2),
+				attempt:   2,
+			},
+			want:   4 * time.Second,
+			wantOk: true,
+		},
+
+		{
+			name: "[SUCCESS] Should stop when the attempts are exhausted",
+			args: args{
+				durations: BackoffExponential(3, 1*time.Second, 2),
+				attempt:   3,
+			},
+			want:   0,
+			wantOk: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				got, ok := BackoffSlice(test.args.durations).Next(test.args.attempt, nil)
+				assert.Equal(t, test.want, got)
+				assert.Equal(t, test.wantOk, ok)
+			})
+		})
+	}
+}
+
+func Test_BackoffFibonacci(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		attempts int
+		amount   time.Duration
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want []time.Duration
+	}{
+		{
+			name: "[SUCCESS] Should return the Fibonacci sequence multiplied by the amount",
+			args: args{
+				attempts: 6,
+				amount:   1 * time.Second,
+			},
+			want: []time.Duration{1 * time.Second, 1 * time.Second, 2 * time.Second, 3 * time.Second, 5 * time.Second, 8 * time.Second},
+		},
+
+		{
+			name: "[SUCCESS] Should return no delays when there are no attempts",
+			args: args{
+				attempts: 0,
+				amount:   1 * time.Second,
+			},
+			want: []time.Duration{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				got := collectBackoff(BackoffFibonacci(test.args.attempts, test.args.amount), 10)
+				assert.Equal(t, test.want, got)
+			})
+		})
+	}
+}
+
+func Test_BackoffJitter(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		backoff Backoff
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		lower []time.Duration
+		upper []time.Duration
+	}{
+		{
+			name: "[SUCCESS] Should return full jitter delays",
+			args: args{
+				backoff: BackoffFullJitter(4, 1*time.Second, 3*time.Second),
+			},
+			lower: []time.Duration{0, 0, 0, 0},
+			upper: []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+
+		{
+			name: "[SUCCESS] Should return equal jitter delays",
+			args: args{
+				backoff: BackoffEqualJitter(4, 1*time.Second, 3*time.Second),
+			},
+			lower: []time.Duration{500 * time.Millisecond, 1 * time.Second, 1500 * time.Millisecond, 1500 * time.Millisecond},
+			upper: []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+
+		{
+			name: "[SUCCESS] Should return decorrelated jitter delays",
+			args: args{
+				backoff: BackoffDecorrelatedJitter(4, 1*time.Second, 5*time.Second),
+			},
+			lower: []time.Duration{1 * time.Second, 1 * time.Second, 1 * time.Second, 1 * time.Second},
+			upper: []time.Duration{3 * time.Second, 5 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				got := collectBackoff(test.args.backoff, 10)
+				assert.Len(t, got, len(test.lower))
+				for i := range got {
+					assert.GreaterOrEqual(t, got[i], test.lower[i])
+					assert.LessOrEqual(t, got[i], test.upper[i])
+				}
+			})
+		})
+	}
+}
+
+func Test_BackoffUnlimited(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		backoff Backoff
+	}{
+		{
+			name:    "[SUCCESS] Should retry forever with a Fibonacci backoff",
+			backoff: BackoffFibonacci(-1, 1*time.Second),
+		},
+
+		{
+			name:    "[SUCCESS] Should retry forever with a full jitter backoff",
+			backoff: BackoffFullJitter(-1, 1*time.Second, 1*time.Minute),
+		},
+
+		{
+			name:    "[SUCCESS] Should retry forever with a decorrelated jitter backoff",
+			backoff: BackoffDecorrelatedJitter(-1, 1*time.Second, 1*time.Minute),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				got := collectBackoff(test.backoff, 200)
+				assert.Len(t, got, 200)
+				assert.Greater(t, got[199], time.Duration(0))
+			})
+		})
+	}
+}
+
+func collectBackoff(backoff Backoff, limit int) []time.Duration {
+	ret := make([]time.Duration, 0)
+	for i := 0; i < limit; i++ {
+		delay, ok := backoff.Next(i, nil)
+		if !ok {
+			break
+		}
+		ret = append(ret, delay)
+	}
+	return ret
+}
@@ -1,12 +1,22 @@
 package sagas
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
+
+// ErrSkipped is the error an action returns to indicate that its work does not need to be done. The built-in
+// classifiers classify it as Skipped and the Step is not considered failed.
+var ErrSkipped = errors.New("step skipped")
 
 // Classifier is the interface to classify errors. It is used to determine
-// whether an action should be retried or not.
+// whether an action should be retried or not. The Retrier retries the action
+// only when the status is Retry; any other status stops the retries.
 type Classifier interface {
 	// Classify receives an error and returns a Status. If the error is nil, it
-	// returns Successed; if the error is not nil, it returns Retry.
+	// returns Successed; if the error is not nil, it returns Retry. It can also
+	// return Failed to fail fast, Canceled to stop without failing the Step and
+	// Skipped to skip the Step.
 	Classify(error) Status
 }
 
@@ -14,7 +24,9 @@ type classifier struct{}
 
 // NewClassifier creates a new default classifier. It is the default
 // classifier used if no classifier is provided. If the error is nil, it
-// returns Successed, otherwise it returns Retry. Example:
+// returns Successed, if the error is context.Canceled, it returns Canceled,
+// if the error is ErrSkipped, it returns Skipped, otherwise it returns Retry.
+// Example:
 //
 //	classifier := sagas.NewClassifier()
 //
@@ -29,7 +41,11 @@ func (c classifier) Classify(err error) Status {
 		return Successed
 	}
 
-	return retry
+	if status, ok := classifyBuiltin(err); ok {
+		return status
+	}
+
+	return Retry
 }
 
 type classifierWhitelist []error
@@ -50,9 +66,13 @@ func (list classifierWhitelist) Classify(err error) Status {
 		return Successed
 	}
 
+	if status, ok := classifyBuiltin(err); ok {
+		return status
+	}
+
 	for _, pass := range list {
 		if errors.Is(err, pass) {
-			return retry
+			return Retry
 		}
 	}
 
@@ -77,11 +97,184 @@ func (list classifierBlacklist) Classify(err error) Status {
 		return Successed
 	}
 
+	if status, ok := classifyBuiltin(err); ok {
+		return status
+	}
+
 	for _, pass := range list {
 		if errors.Is(err, pass) {
 			return Failed
 		}
 	}
 
-	return retry
+	return Retry
+}
+
+// classifyBuiltin classifies the errors that have the same meaning for every built-in classifier. It returns
+// Canceled for context.Canceled and Skipped for ErrSkipped. The returned boolean is false for any other error.
+func classifyBuiltin(err error) (Status, bool) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return Canceled, true
+	case errors.Is(err, ErrSkipped):
+		return Skipped, true
+	default:
+		return Undefined, false
+	}
+}
+
+// ClassifierFunc is a function type that implements the Classifier interface. Example:
+//
+//	classifier := sagas.ClassifierFunc(func(err error) sagas.Status {
+//		if err == nil {
+//			return sagas.Successed
+//		}
+//		return sagas.Failed
+//	})
+//
+// The above example will create a new classifier that never retries.
+type ClassifierFunc func(error) Status
+
+// Classify calls the function itself.
+func (f ClassifierFunc) Classify(err error) Status {
+	return f(err)
+}
+
+// NewClassifierIs creates a new classifier that matches errors through errors.Is. If the error is nil, it
+// returns Successed; if the error matches any of the targets, it returns the given status; otherwise, it returns
+// Undefined, so that it can be combined with FirstMatch and Chain. Example:
+//
+//	classifier := sagas.NewClassifierIs(sagas.Retry, ErrServiceUnavailable)
+//
+// The above example will create a new classifier that will return Retry if the error is ErrServiceUnavailable.
+func NewClassifierIs(status Status, targets ...error) Classifier {
+	return ClassifierFunc(func(err error) Status {
+		if err == nil {
+			return Successed
+		}
+
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return status
+			}
+		}
+
+		return Undefined
+	})
+}
+
+// NewClassifierAs creates a new classifier that matches errors by type through errors.As. If the error is nil,
+// it returns Successed; if any error in the error's tree is of type T, it returns the given status; otherwise,
+// it returns Undefined, so that it can be combined with FirstMatch and Chain. Example:
+//
+//	classifier := sagas.NewClassifierAs[*ValidationError](sagas.Failed)
+//
+// The above example will create a new classifier that will return Failed if the error is a *ValidationError.
+func NewClassifierAs[T error](status Status) Classifier {
+	return ClassifierFunc(func(err error) Status {
+		if err == nil {
+			return Successed
+		}
+
+		var target T
+		if errors.As(err, &target) {
+			return status
+		}
+
+		return Undefined
+	})
+}
+
+// NewClassifierRetryable creates a new classifier that asks the error whether it should be retried. If the
+// error is nil, it returns Successed; if any error in the error's tree implements Retryable() bool or
+// Temporary() bool, it returns Retry when the method returns true and Failed otherwise; if no error implements
+// them, it returns Undefined, so that it can be combined with FirstMatch and Chain. Example:
+//
+//	classifier := sagas.NewClassifierRetryable()
+//
+// The above example will create a new classifier that will return Retry for a net.Error whose Temporary
+// method returns true.
+func NewClassifierRetryable() Classifier {
+	return ClassifierFunc(func(err error) Status {
+		if err == nil {
+			return Successed
+		}
+
+		var retryable interface{ Retryable() bool }
+		if errors.As(err, &retryable) {
+			return retryStatus(retryable.Retryable())
+		}
+
+		var temporary interface{ Temporary() bool }
+		if errors.As(err, &temporary) {
+			return retryStatus(temporary.Temporary())
+		}
+
+		return Undefined
+	})
+}
+
+// retryStatus returns Retry if the given boolean is true, otherwise it returns Failed.
+func retryStatus(ok bool) Status {
+	if ok {
+		return Retry
+	}
+	return Failed
+}
+
+// FirstMatch creates a new classifier that classifies the error with each of the given classifiers in turn and
+// returns the first status that is not Undefined. If no classifier matches, it returns Undefined, so that it
+// can be nested in other combinators. Example:
+//
+//	classifier := sagas.FirstMatch(
+//		sagas.NewClassifierIs(sagas.Failed, ErrValidation),
+//		sagas.NewClassifierRetryable(),
+//	)
+//
+// The above example will create a new classifier that will return Failed for ErrValidation, and otherwise
+// ask the error whether it should be retried.
+func FirstMatch(classifiers ...Classifier) Classifier {
+	return ClassifierFunc(func(err error) Status {
+		for _, c := range classifiers {
+			if status := c.Classify(err); status != Undefined {
+				return status
+			}
+		}
+		return Undefined
+	})
+}
+
+// Chain creates a new classifier that classifies the error with each of the given classifiers in turn and
+// returns the first status that is not Undefined. If no classifier matches, it falls back to the default
+// classifier, which returns Successed if the error is nil and Retry otherwise. Example:
+//
+//	classifier := sagas.Chain(
+//		sagas.NewClassifierAs[net.Error](sagas.Retry),
+//		sagas.NewClassifierIs(sagas.Retry, ErrServiceUnavailable),
+//		sagas.NewClassifierAs[*ValidationError](sagas.Failed),
+//	)
+//
+// The above example will create a new classifier that retries network errors and ErrServiceUnavailable, fails
+// on validation errors and retries any other error.
+func Chain(classifiers ...Classifier) Classifier {
+	return FirstMatch(append(append([]Classifier(nil), classifiers...), NewClassifier())...)
+}
+
+// Not creates a new classifier that inverts the decision of the given classifier, returning Failed where it
+// returns Retry and Retry where it returns Failed. Any other status is returned as is. Example:
+//
+//	classifier := sagas.Not(sagas.NewClassifierIs(sagas.Failed, ErrTimeout))
+//
+// The above example will create a new classifier that will return Retry if the error is ErrTimeout.
+func Not(classifier Classifier) Classifier {
+	return ClassifierFunc(func(err error) Status {
+		switch status := classifier.Classify(err); status {
+		case Retry:
+			return Failed
+		case Failed:
+			return Retry
+		default:
+			return status
+		}
+	})
 }
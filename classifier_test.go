@@ -1,7 +1,9 @@
 package sagas
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,7 +34,23 @@ func Test_defaultClassifier_Classify(t *testing.T) {
 			args: args{
 				err: assert.AnError,
 			},
-			want: retry,
+			want: Retry,
+		},
+
+		{
+			name: "[SUCESS] Should return Canceled if the error is context.Canceled",
+			args: args{
+				err: fmt.Errorf("wrapped: %w", context.Canceled),
+			},
+			want: Canceled,
+		},
+
+		{
+			name: "[SUCESS] Should return Skipped if the error is ErrSkipped",
+			args: args{
+				err: ErrSkipped,
+			},
+			want: Skipped,
 		},
 	}
 
@@ -81,7 +99,7 @@ func Test_whitelistClassifier_Classify(t *testing.T) {
 				},
 				err: assert.AnError,
 			},
-			want: retry,
+			want: Retry,
 		},
 
 		{
@@ -152,7 +170,7 @@ func Test_blacklistClassifier_Classify(t *testing.T) {
 				},
 				err: errors.New("not in the blacklist"),
 			},
-			want: retry,
+			want: Retry,
 		},
 	}
 
@@ -168,3 +186,197 @@ func Test_blacklistClassifier_Classify(t *testing.T) {
 		})
 	}
 }
+
+type validationError struct{}
+
+func (validationError) Error() string { return "validation error" }
+
+type retryableError bool
+
+func (e retryableError) Error() string   { return "retryable error" }
+func (e retryableError) Retryable() bool { return bool(e) }
+
+type temporaryError bool
+
+func (e temporaryError) Error() string   { return "temporary error" }
+func (e temporaryError) Temporary() bool { return bool(e) }
+
+func Test_composableClassifiers_Classify(t *testing.T) {
+	t.Parallel()
+
+	errTimeout := errors.New("timeout")
+
+	type args struct {
+		classifier Classifier
+		err        error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want Status
+	}{
+		{
+			name: "[SUCESS] ClassifierFunc should return the status of the function",
+			args: args{
+				classifier: ClassifierFunc(func(error) Status { return Failed }),
+				err:        assert.AnError,
+			},
+			want: Failed,
+		},
+
+		{
+			name: "[SUCESS] NewClassifierIs should return Successed if the error is nil",
+			args: args{
+				classifier: NewClassifierIs(Failed, errTimeout),
+				err:        nil,
+			},
+			want: Successed,
+		},
+
+		{
+			name: "[SUCESS] NewClassifierIs should return the status if the error matches",
+			args: args{
+				classifier: NewClassifierIs(Failed, errTimeout),
+				err:        fmt.Errorf("wrapped: %w", errTimeout),
+			},
+			want: Failed,
+		},
+
+		{
+			name: "[SUCESS] NewClassifierIs should return Undefined if the error does not match",
+			args: args{
+				classifier: NewClassifierIs(Failed, errTimeout),
+				err:        assert.AnError,
+			},
+			want: Undefined,
+		},
+
+		{
+			name: "[SUCESS] NewClassifierAs should return the status if the error type matches",
+			args: args{
+				classifier: NewClassifierAs[validationError](Failed),
+				err:        fmt.Errorf("wrapped: %w", validationError{}),
+			},
+			want: Failed,
+		},
+
+		{
+			name: "[SUCESS] NewClassifierAs should return Undefined if the error type does not match",
+			args: args{
+				classifier: NewClassifierAs[validationError](Failed),
+				err:        assert.AnError,
+			},
+			want: Undefined,
+		},
+
+		{
+			name: "[SUCESS] NewClassifierRetryable should return Retry for retryable errors",
+			args: args{
+				classifier: NewClassifierRetryable(),
+				err:        retryableError(true),
+			},
+			want: Retry,
+		},
+
+		{
+			name: "[SUCESS] NewClassifierRetryable should return Failed for non temporary errors",
+			args: args{
+				classifier: NewClassifierRetryable(),
+				err:        temporaryError(false),
+			},
+			want: Failed,
+		},
+
+		{
+			name: "[SUCESS] NewClassifierRetryable should return Undefined for other errors",
+			args: args{
+				classifier: NewClassifierRetryable(),
+				err:        assert.AnError,
+			},
+			want: Undefined,
+		},
+
+		{
+			name: "[SUCESS] FirstMatch should return the first status that is not Undefined",
+			args: args{
+				classifier: FirstMatch(NewClassifierIs(Failed, errTimeout), NewClassifierRetryable()),
+				err:        temporaryError(true),
+			},
+			want: Retry,
+		},
+
+		{
+			name: "[SUCESS] FirstMatch should return Undefined if no classifier matches",
+			args: args{
+				classifier: FirstMatch(NewClassifierIs(Failed, errTimeout), NewClassifierRetryable()),
+				err:        assert.AnError,
+			},
+			want: Undefined,
+		},
+
+		{
+			name: "[SUCESS] Chain should fall back to Retry if no classifier matches",
+			args: args{
+				classifier: Chain(NewClassifierAs[validationError](Failed)),
+				err:        assert.AnError,
+			},
+			want: Retry,
+		},
+
+		{
+			name: "[SUCESS] Chain should return the status of the matching classifier",
+			args: args{
+				classifier: Chain(NewClassifierRetryable(), NewClassifierAs[validationError](Failed)),
+				err:        validationError{},
+			},
+			want: Failed,
+		},
+
+		{
+			name: "[SUCESS] Not should invert Failed into Retry",
+			args: args{
+				classifier: Not(NewClassifierIs(Failed, errTimeout)),
+				err:        errTimeout,
+			},
+			want: Retry,
+		},
+
+		{
+			name: "[SUCESS] Not should keep Successed",
+			args: args{
+				classifier: Not(NewClassifierIs(Failed, errTimeout)),
+				err:        nil,
+			},
+			want: Successed,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				got := test.args.classifier.Classify(test.args.err)
+				assert.Equal(t, test.want, got)
+			})
+		})
+	}
+}
+
+func Test_Chain_KeepsClassifiers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should not change the slice of classifiers given to Chain", func(t *testing.T) {
+		t.Parallel()
+		errDeclined := errors.New("declined")
+		classifiers := make([]Classifier, 1, 2)
+		classifiers[0] = NewClassifierIs(Failed, errDeclined)
+		kept := classifiers[:2]
+		kept[1] = NewClassifierIs(Skipped, assert.AnError)
+
+		_ = Chain(classifiers...)
+
+		assert.Equal(t, Skipped, kept[1].Classify(assert.AnError))
+	})
+}
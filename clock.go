@@ -0,0 +1,85 @@
+package sagas
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the interface that wraps the methods to read the time and wait for it. It is injected in the
+// components that depend on the time, so they can be tested without waiting for real time to pass.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a new Timer that sends the current time on its channel after the duration.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is the interface that wraps the methods of a single event created by a Clock.
+type Timer interface {
+	// C returns the channel on which the time is sent when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if the timer already fired or was stopped.
+	Stop() bool
+}
+
+// systemClock is the Clock that reads the time of the system.
+type systemClock struct{}
+
+// NewSystemClock returns a Clock that reads the time of the system. It is the clock used when none is
+// provided. Example:
+//
+//	scheduler := sagas.NewScheduler(sagas.NewSystemClock())
+//
+// The above example will create a scheduler that runs the functions at the time of the system.
+func NewSystemClock() Clock {
+	return systemClock{}
+}
+
+// Now returns the current time of the system.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse in the time of the system.
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTimer creates a new Timer that fires in the time of the system.
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{timer: time.NewTimer(d)}
+}
+
+// systemTimer is the Timer created by the system clock.
+type systemTimer struct {
+	timer *time.Timer
+}
+
+// C returns the channel of the timer.
+func (t systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+// Stop stops the timer.
+func (t systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// clockKey is the context key of the Clock of the saga or the step.
+type clockKey struct{}
+
+// withClock returns a copy of the context that carries the given Clock.
+func withClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// clockFromContext returns the Clock carried by the context. If there is none, it returns the clock of the
+// system.
+func clockFromContext(ctx context.Context) Clock {
+	if clock, ok := ctx.Value(clockKey{}).(Clock); ok && clock != nil {
+		return clock
+	}
+	return systemClock{}
+}
@@ -0,0 +1,43 @@
+package sagas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewSystemClock(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		delay time.Duration
+	}{
+		{
+			name:  "[SUCCESS] Should send the time once the duration elapsed",
+			delay: 10 * time.Millisecond,
+		},
+
+		{
+			name:  "[SUCCESS] Should send the time right away when the duration is not positive",
+			delay: -time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			clock := NewSystemClock()
+			before := clock.Now()
+
+			select {
+			case at := <-clock.After(test.delay):
+				assert.False(t, at.Before(before.Add(test.delay)))
+			case <-time.After(time.Second):
+				t.Fatal("the clock did not send the time")
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package sagas
+
+// Codec is the interface that wraps the methods to serialize the notifications sent through a Transport.
+type Codec interface {
+	// Encode serializes the notification.
+	Encode(notification Notification) ([]byte, error)
+	// Decode deserializes a notification serialized by Encode.
+	Decode(data []byte) (Notification, error)
+}
+
+// jsonCodec is a JSON implementation of the Codec interface.
+type jsonCodec struct{}
+
+// NewJSONCodec returns a new Codec that serializes the notifications as JSON, wrapped in a versioned Envelope.
+// The error of an attempt is serialized as its message, so it is decoded as a plain error. Example:
+//
+//	codec := sagas.NewJSONCodec()
+//
+//	data, err := codec.Encode(notification)
+//
+// The above example will serialize the notification as JSON.
+func NewJSONCodec() Codec {
+	return jsonCodec{}
+}
+
+// Encode serializes the notification with MarshalEnvelope.
+func (jsonCodec) Encode(notification Notification) ([]byte, error) {
+	return MarshalEnvelope(notification)
+}
+
+// Decode deserializes a notification with UnmarshalEnvelope.
+func (jsonCodec) Decode(data []byte) (Notification, error) {
+	return UnmarshalEnvelope(data)
+}
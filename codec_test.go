@@ -0,0 +1,127 @@
+package sagas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jsonCodec(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		notification Notification
+	}{
+		{
+			name:         "[SUCCESS] Should round trip a state notification",
+			notification: Notification{Identifier: NewStableIdentifier("orders.create"), Event: Completed},
+		},
+
+		{
+			name:         "[SUCCESS] Should round trip a status notification",
+			notification: Notification{Identifier: NewStableIdentifier("orders.create"), Event: Canceled},
+		},
+
+		{
+			name: "[SUCCESS] Should round trip the attempt of a retrying notification",
+			notification: Notification{
+				Identifier: NewStableIdentifier("orders.create"),
+				Event:      Retrying,
+				Attempt: &Attempt{
+					Number:    2,
+					StartedAt: startedAt,
+					EndedAt:   startedAt.Add(time.Second),
+					Err:       assert.AnError,
+					Status:    Retry,
+					NextDelay: 3 * time.Second,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				codec := NewJSONCodec()
+
+				data, err := codec.Encode(test.notification)
+				assert.NoError(t, err)
+
+				got, err := codec.Decode(data)
+				assert.NoError(t, err)
+				assert.Equal(t, test.notification.Identifier, got.Identifier)
+				assert.Equal(t, test.notification.Event, got.Event)
+				if test.notification.Attempt == nil {
+					assert.Nil(t, got.Attempt)
+					return
+				}
+				assert.EqualError(t, got.Attempt.Err, test.notification.Attempt.Err.Error())
+				got.Attempt.Err = test.notification.Attempt.Err
+				assert.Equal(t, test.notification.Attempt, got.Attempt)
+			})
+		})
+	}
+}
+
+func Test_jsonCodec_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		notification *Notification
+		data         string
+	}{
+		{
+			name:         "[FAILURE] Should not encode a notification without identifier",
+			notification: &Notification{Event: Completed},
+		},
+
+		{
+			name:         "[FAILURE] Should not encode a notification without event",
+			notification: &Notification{Identifier: NewStableIdentifier("step")},
+		},
+
+		{
+			name: "[FAILURE] Should not decode invalid JSON",
+			data: "{",
+		},
+
+		{
+			name: "[FAILURE] Should not decode an unsupported envelope version",
+			data: `{"version":2,"notification":{"identifier":"step","event":"Completed"}}`,
+		},
+
+		{
+			name: "[FAILURE] Should not decode an unknown event",
+			data: `{"version":1,"notification":{"identifier":"step","event":"Exploded"}}`,
+		},
+
+		{
+			name: "[FAILURE] Should not decode an attempt with an unknown status",
+			data: `{"version":1,"notification":{"identifier":"step","event":"Retrying","attempt":{"status":"Running"}}}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				codec := NewJSONCodec()
+				if test.notification != nil {
+					_, err := codec.Encode(*test.notification)
+					assert.Error(t, err)
+					return
+				}
+				_, err := codec.Decode([]byte(test.data))
+				assert.Error(t, err)
+			})
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package sagas
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// NewDelayStep creates a new Step that completes the given delay after it is triggered, so the steps that
+// follow it run later. The step does not block while it waits: its run is scheduled with the Scheduler of the
+// saga and, meanwhile, its state is Waiting and its due time is kept in the SagaStore of the saga, so it can be
+// resumed by another process with Saga.Resume. Example:
+//
+//	wait := sagas.NewDelayStep("wait payment", 30*time.Minute,
+//		sagas.WithStepIdentifier(sagas.NewStableIdentifier("wait payment")))
+//
+//	saga.When(wait).Is(sagas.Successed).Then(cancelUnpaidOrder)
+//
+// The above example will cancel the unpaid order thirty minutes after the wait step is triggered.
+func NewDelayStep(name string, delay time.Duration, options ...StepOption) Step {
+	return NewStep(name, func(context.Context) error { return nil },
+		append([]StepOption{WithStepDelay(delay)}, options...)...)
+}
+
+// schedule postpones the run of the Step until its due time. It reports whether the run was scheduled or
+// refused by the execution policy of the Step, so Run must return right away. A Step run at its scheduled time,
+// or whose due time already passed, is not scheduled.
+func (s *step) schedule(ctx context.Context) (bool, error) {
+	if (s.notBefore.IsZero() && s.delay <= 0) || isScheduled(ctx, s.identifier) {
+		return false, nil
+	}
+
+	s.guard.Lock()
+	ignored, rejected := s.refused()
+	s.guard.Unlock()
+	if ignored || rejected {
+		return true, s.refuse(ctx, rejected)
+	}
+
+	scheduler := s.scheduler
+	if scheduler == nil && s.clock != nil {
+		scheduler = NewScheduler(s.clock)
+	}
+	if scheduler == nil {
+		scheduler = schedulerFromContext(ctx)
+	}
+	p, parked := parkingFromContext(ctx)
+	saga, _ := sagaIdentifierFromContext(ctx)
+
+	due, err := s.due(ctx, p, parked, saga, scheduler.Now())
+	if err != nil {
+		return false, err
+	}
+
+	if !due.After(scheduler.Now()) {
+		if parked {
+			return false, p.store.Unpark(ctx, saga, s.identifier)
+		}
+		return false, nil
+	}
+
+	if parked {
+		if err := p.store.Park(ctx, saga, ParkedStep{Step: s.identifier, Deadline: due}); err != nil {
+			return false, err
+		}
+	}
+	s.setState(ctx, Waiting)
+	logContext(withStepIdentifier(ctx, s.identifier), slog.LevelDebug, "step scheduled", slog.Time("due", due))
+
+	// The function does not run in the worker that scheduled it, so it must not lend the slot of that worker.
+	scheduler.Schedule(withPoolSlot(ctx, nil), due, func(ctx context.Context) {
+		if parked {
+			if err := p.store.Unpark(ctx, saga, s.identifier); err != nil {
+				logContext(withStepIdentifier(ctx, s.identifier), slog.LevelError, "step could not be unparked",
+					slog.Any("error", err))
+			}
+		}
+		_ = s.Run(withScheduled(ctx, s.identifier))
+	})
+	return true, nil
+}
+
+// due returns the time at which the Step must run. A Step that was already parked, before the saga was
+// resumed, keeps its due time.
+func (s *step) due(ctx context.Context, p parking, parked bool, saga Identifier, now time.Time) (time.Time, error) {
+	if parked {
+		steps, err := p.store.Parked(ctx, saga)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		for _, step := range steps {
+			if step.Step.String() == s.identifier.String() {
+				return step.Deadline, nil
+			}
+		}
+	}
+
+	due := now.Add(s.delay)
+	if s.notBefore.After(due) {
+		due = s.notBefore
+	}
+	return due, nil
+}
@@ -0,0 +1,182 @@
+package sagas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewDelayStep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		delay       time.Duration
+		notBefore   time.Duration
+		wantWaiting bool
+	}{
+		{
+			name:        "[SUCCESS] Should complete once the delay elapsed",
+			delay:       50 * time.Millisecond,
+			wantWaiting: true,
+		},
+
+		{
+			name:        "[SUCCESS] Should wait until the not-before time when it is later than the delay",
+			delay:       time.Millisecond,
+			notBefore:   50 * time.Millisecond,
+			wantWaiting: true,
+		},
+
+		{
+			name:        "[SUCCESS] Should run right away when the not-before time already passed",
+			notBefore:   -time.Hour,
+			wantWaiting: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			startedAt := time.Now()
+			var options []StepOption
+			if test.notBefore != 0 {
+				options = append(options, WithStepNotBefore(startedAt.Add(test.notBefore)))
+			}
+
+			store := NewMemorySagaStore()
+			delay := NewDelayStep("delay", test.delay, options...)
+			c := NewSaga(WithSagaStore(store))
+			c.AddSteps(delay)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				c.Run(context.Background(), func() bool { return delay.GetStatus() == Successed })
+			}()
+
+			if test.wantWaiting {
+				assert.Eventually(t, func() bool {
+					parked, _ := store.Parked(context.Background(), c.GetIdentifier())
+					return len(parked) == 1
+				}, time.Second, time.Millisecond)
+			}
+			<-done
+
+			assert.Equal(t, Successed, delay.GetStatus())
+			if test.wantWaiting {
+				assert.GreaterOrEqual(t, time.Since(startedAt), 50*time.Millisecond)
+			}
+			assert.Eventually(t, func() bool {
+				parked, _ := store.Parked(context.Background(), c.GetIdentifier())
+				return len(parked) == 0
+			}, time.Second, time.Millisecond)
+		})
+	}
+}
+
+func Test_NewDelayStep_Resume(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := NewMemorySagaStore()
+	identifier := NewStableIdentifier("order")
+	delay := NewDelayStep("delay", time.Hour, WithStepIdentifier(NewStableIdentifier("delay")))
+
+	c := NewSaga(WithSagaIdentifier(identifier), WithSagaStore(store))
+	c.AddSteps(delay)
+	assert.NoError(t, store.Park(ctx, identifier, ParkedStep{
+		Step:     delay.GetIdentifier(),
+		Deadline: time.Now().Add(-time.Minute),
+	}))
+
+	assert.NoError(t, c.Resume(ctx, func() bool { return delay.GetStatus() == Successed }))
+
+	assert.Equal(t, Successed, delay.GetStatus())
+	parked, err := store.Parked(ctx, identifier)
+	assert.NoError(t, err)
+	assert.Empty(t, parked)
+}
+
+func Test_WithStepScheduler(t *testing.T) {
+	t.Parallel()
+	scheduled := make(chan time.Time, 1)
+	scheduler := &recordingScheduler{Scheduler: NewScheduler(NewSystemClock()), scheduled: scheduled}
+	notBefore := time.Now().Add(20 * time.Millisecond)
+	s := NewStep("step", func(context.Context) error { return nil },
+		WithStepNotBefore(notBefore), WithStepScheduler(scheduler))
+
+	assert.NoError(t, s.Run(context.Background()))
+	assert.Equal(t, Waiting, s.GetState())
+	assert.Equal(t, notBefore, <-scheduled)
+	assert.Eventually(t, func() bool { return s.GetStatus() == Successed }, time.Second, time.Millisecond)
+}
+
+// recordingScheduler is a Scheduler that records the times of the scheduled functions.
+type recordingScheduler struct {
+	Scheduler
+	scheduled chan time.Time
+}
+
+// Schedule records the time and schedules the function.
+func (s *recordingScheduler) Schedule(ctx context.Context, at time.Time, fn func(context.Context)) {
+	s.scheduled <- at
+	s.Scheduler.Schedule(ctx, at, fn)
+}
+
+func Test_NewDelayStep_WithSagaClock(t *testing.T) {
+	t.Parallel()
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	delay := NewDelayStep("delay", 30*time.Minute)
+	c := NewSaga(WithSagaClock(clock))
+	c.AddSteps(delay)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Run(context.Background(), func() bool { return delay.GetStatus() == Successed })
+	}()
+
+	assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, Waiting, delay.GetState())
+	clock.Advance(30 * time.Minute)
+	<-done
+
+	assert.Equal(t, Successed, delay.GetStatus())
+}
+
+func Test_NewDelayStep_Chained(t *testing.T) {
+	t.Parallel()
+	first := NewDelayStep("first", 50*time.Millisecond)
+	second := NewDelayStep("second", 100*time.Millisecond)
+
+	c := NewSaga()
+	c.AddSteps(first, second)
+	c.When(first).Is(Successed).Then(NewAction(second.Run)).Plan()
+
+	startedAt := time.Now()
+	c.Run(context.Background(), func() bool { return second.GetStatus() == Successed })
+
+	assert.GreaterOrEqual(t, time.Since(startedAt), 150*time.Millisecond)
+}
+
+func Test_NewDelayStep_RunOnce(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := NewMemorySagaStore()
+	delay := NewDelayStep("delay", 20*time.Millisecond, WithStepExecutionPolicy(RunOnce))
+
+	c := NewSaga(WithSagaStore(store))
+	c.AddSteps(delay)
+	c.Run(ctx, func() bool { return delay.GetState() == Completed })
+
+	ctx = withParking(withSagaIdentifier(ctx, c.GetIdentifier()), parking{store: store, hub: newSignalHub()})
+	assert.NoError(t, delay.Run(ctx))
+
+	assert.Equal(t, Completed, delay.GetState())
+	parked, err := store.Parked(ctx, c.GetIdentifier())
+	assert.NoError(t, err)
+	assert.Empty(t, parked)
+}
@@ -0,0 +1,51 @@
+package sagas
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EnvelopeVersion is the version of the envelope format written by MarshalEnvelope.
+const EnvelopeVersion = 1
+
+// Envelope is the versioned format of a notification persisted or transported between processes. The version
+// allows the format of the notification to evolve while the records written by older versions are still read.
+type Envelope struct {
+	// Version is the version of the envelope format.
+	Version int `json:"version"`
+	// Notification is the notification carried by the envelope.
+	Notification Notification `json:"notification"`
+}
+
+// MarshalEnvelope returns the notification wrapped in an envelope of the current version, as JSON. Example:
+//
+//	data, err := sagas.MarshalEnvelope(notification)
+//
+// The above example will serialize the notification as {"version":1,"notification":{...}}.
+func MarshalEnvelope(notification Notification) ([]byte, error) {
+	return json.Marshal(Envelope{
+		Version:      EnvelopeVersion,
+		Notification: notification,
+	})
+}
+
+// UnmarshalEnvelope returns the notification of an envelope serialized by MarshalEnvelope. It returns an error
+// if the version of the envelope is not supported.
+func UnmarshalEnvelope(data []byte) (Notification, error) {
+	header := struct {
+		Version int `json:"version"`
+	}{}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return Notification{}, err
+	}
+
+	if header.Version != EnvelopeVersion {
+		return Notification{}, fmt.Errorf("unsupported envelope version %d", header.Version)
+	}
+
+	envelope := Envelope{}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return Notification{}, err
+	}
+	return envelope.Notification, nil
+}
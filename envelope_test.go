@@ -0,0 +1,53 @@
+package sagas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Envelope(t *testing.T) {
+	t.Parallel()
+
+	notification := Notification{Identifier: NewStableIdentifier("step"), Event: Completed}
+
+	t.Run("[SUCCESS] Should wrap the notification in a versioned envelope", func(t *testing.T) {
+		t.Parallel()
+		data, err := MarshalEnvelope(notification)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"version":1,"notification":{"identifier":"step","event":"Completed"}}`, string(data))
+
+		got, err := UnmarshalEnvelope(data)
+		assert.NoError(t, err)
+		assert.Equal(t, notification, got)
+	})
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "[FAILURE] Should not unwrap an envelope of another version",
+			data: `{"version":2,"notification":{"identifier":"step","event":"Completed"}}`,
+		},
+
+		{
+			name: "[FAILURE] Should not unwrap an envelope without version",
+			data: `{"notification":{"identifier":"step","event":"Completed"}}`,
+		},
+
+		{
+			name: "[FAILURE] Should not unwrap an invalid notification",
+			data: `{"version":1,"notification":{"identifier":"","event":"Completed"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := UnmarshalEnvelope([]byte(test.data))
+			assert.Error(t, err)
+		})
+	}
+}
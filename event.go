@@ -1,6 +1,17 @@
 package sagas
 
-var callableEventList = []Event{Running, Completed, Failed, Successed}
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var callableEventList = []Event{Running, Completed, Retrying, Failed, Successed, Canceled, Skipped, Rejected, Waiting}
+
+// statusList and stateList are every valid Status and State, used to parse them from their names.
+var (
+	statusList = []Status{Undefined, Failed, Successed, Retry, Canceled, Skipped, Rejected}
+	stateList  = []State{Idle, Running, Completed, Retrying, Waiting}
+)
 
 // Event is an interface that represents a state or status Event.
 // It is used to define the type of the Event in the notification struct and
@@ -11,7 +22,7 @@ type Event interface {
 }
 
 // Status is the status of a Step. It can be one of the following:
-// Undefined, Canceled, Failed, Successed, Retry.
+// Undefined, Failed, Successed, Retry, Canceled, Skipped, Rejected.
 type Status int
 
 const (
@@ -24,9 +35,18 @@ const (
 	// Successed indicates the Step status should treat this value as a success. This is the value that will be
 	// returned if the Step action succeeds before the maximum number of retries is reached.
 	Successed
-	// retry indicates the retrier should treat this value as a soft failure and retry. This is a internal value
-	// and should not be used by the user.
-	retry
+	// Retry indicates the retrier should treat this value as a soft failure and retry. It is returned by a
+	// Classifier and is never the final status of a Step.
+	Retry
+	// Canceled indicates the Step status should treat this value as a cancellation. This is the value that will be
+	// returned if the context of the Step is canceled, which is not a failure of the Step action itself.
+	Canceled
+	// Skipped indicates the Step status should treat this value as a skipped execution. This is the value that will
+	// be returned if the Step action returns ErrSkipped or its Classifier decides that the action should be skipped.
+	Skipped
+	// Rejected indicates that a Step with the Reject execution policy was triggered while it was running. It is only
+	// notified, along with ErrStepRunning, and is never the status of the Step, which keeps running.
+	Rejected
 )
 
 // String returns the string representation of the status.
@@ -38,15 +58,72 @@ func (s Status) String() string {
 		return "Failed"
 	case Successed:
 		return "Successed"
-	case retry:
+	case Retry:
 		return "Retry"
+	case Canceled:
+		return "Canceled"
+	case Skipped:
+		return "Skipped"
+	case Rejected:
+		return "Rejected"
 	default:
 		return "invalid status"
 	}
 }
 
+// ParseStatus returns the Status whose string representation is the given name. It returns an error if the
+// name is not a valid status. Example:
+//
+//	status, err := sagas.ParseStatus("Successed")
+//
+// The above example will return the Successed status.
+func ParseStatus(name string) (Status, error) {
+	for _, status := range statusList {
+		if status.String() == name {
+			return status, nil
+		}
+	}
+	return Undefined, fmt.Errorf("invalid status %q", name)
+}
+
+// MarshalText returns the name of the status. It returns an error if the status is not valid.
+func (s Status) MarshalText() ([]byte, error) {
+	if _, err := ParseStatus(s.String()); err != nil {
+		return nil, err
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses the name of a status.
+func (s *Status) UnmarshalText(text []byte) error {
+	status, err := ParseStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = status
+	return nil
+}
+
+// MarshalJSON returns the name of the status as a JSON string.
+func (s Status) MarshalJSON() ([]byte, error) {
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON parses the name of a status from a JSON string.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(name))
+}
+
 // State is the state of a step. It can be one of the following:
-// Idle, Running, Completed.
+// Idle, Running, Completed, Retrying, Waiting.
 type State int
 
 const (
@@ -59,6 +136,13 @@ const (
 	// Completed indicates that step state should treat this value as a state that has been executed. This is the value
 	// that will be returned if the Step action has been executed.
 	Completed
+	// Retrying indicates that step state should treat this value as a state that is waiting for the next attempt.
+	// This is the value notified after a failed attempt that will be retried, along with the Attempt. The state
+	// returned by the Step remains Running while it is retrying.
+	Retrying
+	// Waiting indicates that step state should treat this value as a parked state. This is the value notified and
+	// returned while a wait step waits for its signal or a delayed step waits for its time.
+	Waiting
 )
 
 // String returns the string representation of the state.
@@ -70,6 +154,75 @@ func (s State) String() string {
 		return "Running"
 	case Completed:
 		return "Completed"
+	case Retrying:
+		return "Retrying"
+	case Waiting:
+		return "Waiting"
 	}
 	return "invalid state"
 }
+
+// ParseState returns the State whose string representation is the given name. It returns an error if the
+// name is not a valid state. Example:
+//
+//	state, err := sagas.ParseState("Completed")
+//
+// The above example will return the Completed state.
+func ParseState(name string) (State, error) {
+	for _, state := range stateList {
+		if state.String() == name {
+			return state, nil
+		}
+	}
+	return Idle, fmt.Errorf("invalid state %q", name)
+}
+
+// MarshalText returns the name of the state. It returns an error if the state is not valid.
+func (s State) MarshalText() ([]byte, error) {
+	if _, err := ParseState(s.String()); err != nil {
+		return nil, err
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses the name of a state.
+func (s *State) UnmarshalText(text []byte) error {
+	state, err := ParseState(string(text))
+	if err != nil {
+		return err
+	}
+	*s = state
+	return nil
+}
+
+// MarshalJSON returns the name of the state as a JSON string.
+func (s State) MarshalJSON() ([]byte, error) {
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON parses the name of a state from a JSON string.
+func (s *State) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(name))
+}
+
+// parseEvent returns the Status or the State whose string representation is the given name. The names of the
+// statuses and the states do not overlap.
+func parseEvent(name string) (Event, error) {
+	if status, err := ParseStatus(name); err == nil {
+		return status, nil
+	}
+
+	if state, err := ParseState(name); err == nil {
+		return state, nil
+	}
+
+	return nil, fmt.Errorf("invalid event %q", name)
+}
@@ -1,6 +1,7 @@
 package sagas
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,11 +38,35 @@ func Test_status_string(t *testing.T) {
 		{
 			name: "[SUCCESS] Status Retry",
 			args: args{
-				s: retry,
+				s: Retry,
 			},
 			want: "Retry",
 		},
 
+		{
+			name: "[SUCCESS] Status Canceled",
+			args: args{
+				s: Canceled,
+			},
+			want: "Canceled",
+		},
+
+		{
+			name: "[SUCCESS] Status Skipped",
+			args: args{
+				s: Skipped,
+			},
+			want: "Skipped",
+		},
+
+		{
+			name: "[SUCCESS] Status Rejected",
+			args: args{
+				s: Rejected,
+			},
+			want: "Rejected",
+		},
+
 		{
 			name: "[SUCCESS] Status Failed",
 			args: args{
@@ -107,6 +132,14 @@ func Test_state_string(t *testing.T) {
 			want: "Completed",
 		},
 
+		{
+			name: "[SUCCESS] State Waiting",
+			args: args{
+				s: Waiting,
+			},
+			want: "Waiting",
+		},
+
 		{
 			name: "[SUCCESS] State Failed",
 			args: args{
@@ -127,3 +160,156 @@ func Test_state_string(t *testing.T) {
 		})
 	}
 }
+
+func Test_ParseStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    Status
+		wantErr bool
+	}{
+		{
+			name: "[SUCCESS] Should parse every status",
+			s:    "Canceled",
+			want: Canceled,
+		},
+
+		{
+			name:    "[FAILURE] Should not parse a state",
+			s:       "Completed",
+			want:    Undefined,
+			wantErr: true,
+		},
+
+		{
+			name:    "[FAILURE] Should not parse an unknown name",
+			s:       "invalid status",
+			want:    Undefined,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseStatus(test.s)
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("[SUCCESS] Should round trip the names of every status", func(t *testing.T) {
+		t.Parallel()
+		for _, status := range statusList {
+			got, err := ParseStatus(status.String())
+			assert.NoError(t, err)
+			assert.Equal(t, status, got)
+		}
+	})
+}
+
+func Test_ParseState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    State
+		wantErr bool
+	}{
+		{
+			name: "[SUCCESS] Should parse every state",
+			s:    "Retrying",
+			want: Retrying,
+		},
+
+		{
+			name:    "[FAILURE] Should not parse a status",
+			s:       "Failed",
+			want:    Idle,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseState(test.s)
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	t.Run("[SUCCESS] Should round trip the names of every state", func(t *testing.T) {
+		t.Parallel()
+		for _, state := range stateList {
+			got, err := ParseState(state.String())
+			assert.NoError(t, err)
+			assert.Equal(t, state, got)
+		}
+	})
+}
+
+func Test_event_JSON(t *testing.T) {
+	t.Parallel()
+
+	type events struct {
+		Status Status `json:"status"`
+		State  State  `json:"state"`
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    events
+		wantErr bool
+	}{
+		{
+			name: "[SUCCESS] Should unmarshal the names of the events",
+			data: `{"status":"Skipped","state":"Running"}`,
+			want: events{Status: Skipped, State: Running},
+		},
+
+		{
+			name:    "[FAILURE] Should not unmarshal an unknown status",
+			data:    `{"status":"Running","state":"Running"}`,
+			wantErr: true,
+		},
+
+		{
+			name:    "[FAILURE] Should not unmarshal a number",
+			data:    `{"status":1,"state":"Running"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := events{}
+			err := json.Unmarshal([]byte(test.data), &got)
+			assert.Equal(t, test.wantErr, err != nil)
+			if test.wantErr {
+				return
+			}
+			assert.Equal(t, test.want, got)
+
+			data, err := json.Marshal(got)
+			assert.NoError(t, err)
+			assert.JSONEq(t, test.data, string(data))
+		})
+	}
+
+	t.Run("[FAILURE] Should not marshal an invalid event", func(t *testing.T) {
+		t.Parallel()
+		_, err := json.Marshal(Status(42))
+		assert.Error(t, err)
+		_, err = json.Marshal(State(42))
+		assert.Error(t, err)
+	})
+}
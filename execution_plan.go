@@ -2,7 +2,6 @@ package sagas
 
 import (
 	"context"
-	"log"
 	"sync"
 )
 
@@ -11,8 +10,8 @@ import (
 type ExecutionPlan interface {
 	// Add adds actions to a given notification of a given identifier in the execution plan.
 	Add(Notification, ...Action)
-	// run executes all actions of a given notification in the execution plan. It runs in parallel.
-	// If the notification does not exist in the execution plan, it does nothing.
+	// run executes all actions of a given notification in the execution plan. It runs in parallel,
+	// except for the actions planned to run sequentially. If the notification does not exist in the execution plan, it does nothing.
 	run(context.Context, Notification)
 }
 
@@ -55,16 +54,21 @@ func (xp *executionPlan) Add(notification Notification, actions ...Action) {
 	xp.plan.add(notification.Identifier, notification.Event, actions...)
 }
 
-// run is a method that executes all actions of a given notification in the execution plan. It runs in parallel.
+// run is a method that executes all actions of a given notification in the execution plan. It runs in parallel,
+// except for the actions planned to run sequentially, which run one after another alongside the parallel ones.
 // If the notification does not exist in the execution plan, it does nothing.
 func (xp *executionPlan) run(ctx context.Context, notification Notification) {
 
 	if actions, ok := xp.plan.get(notification.Identifier, notification.Event); ok {
-		runParallel(ctx, actions, notification)
+		runParallel(withNotification(ctx, notification), actions, notification)
 	}
 }
 
-// runParallel executes all actions in parallel and store the result in the Action.
+// runParallel executes all actions in parallel and store the result in the Action. The errors of the
+// actions are logged with the logger carried by the context. The actions run in the worker pool carried
+// by the context, if any, and the actions of a transition are limited by its options. The sequential
+// transitions are gathered in a single sequence, which runs in parallel with the other actions. It
+// returns once every action ran, lending its worker to the pool meanwhile if it runs in one.
 func runParallel(ctx context.Context, actions []Action, notification Notification) {
 
 	// FIXME: The error is not being handled or returned or stored anywhere.
@@ -74,15 +78,23 @@ func runParallel(ctx context.Context, actions []Action, notification Notificatio
 	// error.
 
 	wg := sync.WaitGroup{}
+	var ordered sequence
 	for _, a := range actions {
+		if t, ok := a.(*transition); ok {
+			if t.sequential {
+				ordered = append(ordered, t)
+				continue
+			}
+			t.dispatch(ctx, &wg)
+			continue
+		}
 		wg.Add(1)
+		dispatch(ctx, a, func(error) { wg.Done() })
+	}
 
-		go func(a Action) {
-			defer wg.Done()
-			err := a.run(ctx)
-			if err != nil {
-				log.Println("error: ", err)
-			}
-		}(a)
+	if len(ordered) > 0 {
+		wg.Add(1)
+		dispatch(ctx, ordered, func(error) { wg.Done() })
 	}
+	blocking(ctx, wg.Wait)
 }
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -151,3 +153,60 @@ func err(xp ExecutionPlan, id Identifier, event Event) error {
 	err := errors.Join(errs...)
 	return err
 }
+
+func Test_runParallel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		pool WorkerPool
+	}{
+		{
+			name: "[SUCCESS] Should return once every action ran",
+		},
+		{
+			name: "[SUCCESS] Should return once every action ran in a worker pool of a single worker",
+			pool: NewWorkerPool(1),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var ran int32
+			action := func() Action {
+				return NewAction(func(context.Context) error {
+					time.Sleep(5 * time.Millisecond)
+					atomic.AddInt32(&ran, 1)
+					return nil
+				})
+			}
+			actions := []Action{
+				action(),
+				action(),
+				newTransition([]Action{action(), action()}, WithTransitionMaxConcurrency(1)),
+				newTransition([]Action{action()}, WithTransitionSequential()),
+			}
+			notification, _ := NewNotification(NewIdentifier("step"), Successed)
+
+			done := make(chan int32)
+			run := func(ctx context.Context) {
+				runParallel(ctx, actions, notification)
+				done <- atomic.LoadInt32(&ran)
+			}
+			if test.pool != nil {
+				test.pool.Go(withWorkerPool(context.Background(), test.pool), run)
+			} else {
+				go run(context.Background())
+			}
+
+			select {
+			case got := <-done:
+				assert.Equal(t, int32(5), got)
+			case <-time.After(time.Second):
+				assert.Fail(t, "runParallel did not return")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package sagas
+
+import "errors"
+
+// ErrStepRunning is returned by a Step with the Reject execution policy when it is triggered while it is running.
+var ErrStepRunning = errors.New("step is already running")
+
+// ExecutionPolicy determines what a Step does when it is triggered again, either while it is running or after
+// it completed. It can be one of the following: RunAlways, RunOnce, Serialize, Reject.
+type ExecutionPolicy int
+
+const (
+	// RunAlways indicates that the Step runs every time it is triggered, even concurrently. This is the default
+	// value.
+	RunAlways ExecutionPolicy = iota
+	// RunOnce indicates that the Step runs only the first time it is triggered. The triggers received while it is
+	// running or after it completed are ignored.
+	RunOnce
+	// Serialize indicates that the Step runs every time it is triggered, one execution at a time. The triggers
+	// received while it is running wait for the previous executions to complete.
+	Serialize
+	// Reject indicates that the triggers received while the Step is running are rejected: Run returns
+	// ErrStepRunning and the Rejected status is notified.
+	Reject
+)
+
+// String returns the string representation of the execution policy.
+func (p ExecutionPolicy) String() string {
+	switch p {
+	case RunAlways:
+		return "RunAlways"
+	case RunOnce:
+		return "RunOnce"
+	case Serialize:
+		return "Serialize"
+	case Reject:
+		return "Reject"
+	default:
+		return "invalid execution policy"
+	}
+}
@@ -0,0 +1,154 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecutionPolicy_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy ExecutionPolicy
+		want   string
+	}{
+		{name: "[SUCCESS] RunAlways", policy: RunAlways, want: "RunAlways"},
+		{name: "[SUCCESS] RunOnce", policy: RunOnce, want: "RunOnce"},
+		{name: "[SUCCESS] Serialize", policy: Serialize, want: "Serialize"},
+		{name: "[SUCCESS] Reject", policy: Reject, want: "Reject"},
+		{name: "[FAILURE] Invalid", policy: ExecutionPolicy(42), want: "invalid execution policy"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, test.policy.String())
+		})
+	}
+}
+
+func Test_step_Run_ExecutionPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		policy         ExecutionPolicy
+		wantCalls      int32
+		wantConcurrent int32
+		wantRejected   int32
+	}{
+		{
+			name:           "[SUCCESS] Should run concurrent triggers in parallel by default",
+			policy:         RunAlways,
+			wantCalls:      3,
+			wantConcurrent: 2,
+		},
+
+		{
+			name:           "[SUCCESS] Should ignore the triggers of a step that runs once",
+			policy:         RunOnce,
+			wantCalls:      1,
+			wantConcurrent: 1,
+		},
+
+		{
+			name:           "[SUCCESS] Should run the triggers of a serialized step one at a time",
+			policy:         Serialize,
+			wantCalls:      3,
+			wantConcurrent: 1,
+		},
+
+		{
+			name:           "[FAILURE] Should reject the triggers received while the step is running",
+			policy:         Reject,
+			wantCalls:      2,
+			wantConcurrent: 1,
+			wantRejected:   1,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				var calls, running, concurrent, rejected atomic.Int32
+				started, unblock := make(chan struct{}, 3), make(chan struct{})
+				s := NewStep("test", func(ctx context.Context) error {
+					calls.Add(1)
+					current := running.Add(1)
+					defer running.Add(-1)
+					for {
+						max := concurrent.Load()
+						if current <= max || concurrent.CompareAndSwap(max, current) {
+							break
+						}
+					}
+					started <- struct{}{}
+					<-unblock
+					return nil
+				}, WithStepExecutionPolicy(test.policy))
+				s.getNotifier().Add(observerFunc(func(_ context.Context, n Notification) {
+					if n.Event == Rejected {
+						rejected.Add(1)
+					}
+				}))
+
+				errs := make(chan error, 2)
+				wg := sync.WaitGroup{}
+				wg.Add(2)
+				go func() { defer wg.Done(); errs <- s.Run(context.Background()) }()
+				<-started
+				go func() { defer wg.Done(); errs <- s.Run(context.Background()) }()
+				time.Sleep(50 * time.Millisecond)
+				close(unblock)
+				wg.Wait()
+				close(errs)
+
+				_ = s.Run(context.Background())
+
+				assert.Equal(t, test.wantCalls, calls.Load())
+				assert.Equal(t, test.wantConcurrent, concurrent.Load())
+				assert.Equal(t, test.wantRejected, rejected.Load())
+				for err := range errs {
+					if err != nil {
+						assert.ErrorIs(t, err, ErrStepRunning)
+					}
+				}
+			})
+		})
+	}
+}
+
+func Test_step_Run_Serialize_Canceled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[FAILURE] Should stop waiting for the previous execution when the context is done", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			started, unblock := make(chan struct{}), make(chan struct{})
+			s := NewStep("test", func(ctx context.Context) error {
+				close(started)
+				<-unblock
+				return nil
+			}, WithStepExecutionPolicy(Serialize))
+
+			go func() { _ = s.Run(context.Background()) }()
+			<-started
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			err := s.Run(ctx)
+			close(unblock)
+
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		})
+	})
+}
@@ -0,0 +1,125 @@
+package sagas
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock whose time only moves when it is advanced. It is used to test the retries, the
+// timeouts and the delayed steps without waiting for real time to pass.
+type FakeClock interface {
+	Clock
+	// Advance moves the time of the clock forward by the duration and fires the timers that are due, in the
+	// order of their deadlines.
+	Advance(d time.Duration)
+	// Waiters returns the number of timers that did not fire yet. It is used to know when the code under test
+	// is waiting for the clock before advancing it.
+	Waiters() int
+}
+
+// fakeClock is the concrete implementation of the FakeClock interface.
+type fakeClock struct {
+	now     time.Time
+	waiters []*fakeTimer
+	mutex   sync.Mutex
+}
+
+// fakeTimer is the Timer created by the fake clock.
+type fakeTimer struct {
+	clock    *fakeClock
+	deadline time.Time
+	channel  chan time.Time
+}
+
+// NewFakeClock returns a new FakeClock whose time starts at the given time. Example:
+//
+//	clock := sagas.NewFakeClock(time.Now())
+//
+//	retrier := sagas.NewRetrier(sagas.BackoffConstant(3, time.Minute), sagas.WithRetrierClock(clock))
+//
+//	go retrier.Retry(ctx, action)
+//
+//	clock.Advance(time.Minute)
+//
+// The above example will retry the action once, without waiting for a minute to pass.
+func NewFakeClock(now time.Time) FakeClock {
+	return &fakeClock{
+		now: now,
+	}
+}
+
+// Now returns the current time of the clock.
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// After sends the time of the clock on the returned channel once it is advanced by the duration.
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a new Timer that fires once the clock is advanced by the duration. A timer whose
+// duration is not positive fires right away.
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	t := &fakeTimer{clock: c, deadline: c.now.Add(d), channel: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.channel <- c.now
+		return t
+	}
+
+	c.waiters = append(c.waiters, t)
+	return t
+}
+
+// Advance moves the time of the clock forward and fires the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].deadline.Before(c.waiters[j].deadline)
+	})
+
+	waiters := c.waiters[:0]
+	for _, t := range c.waiters {
+		if t.deadline.After(c.now) {
+			waiters = append(waiters, t)
+			continue
+		}
+		t.channel <- c.now
+	}
+	c.waiters = waiters
+}
+
+// Waiters returns the number of timers that did not fire yet.
+func (c *fakeClock) Waiters() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.waiters)
+}
+
+// C returns the channel of the timer.
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.channel
+}
+
+// Stop removes the timer from the clock.
+func (t *fakeTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	for i, waiter := range t.clock.waiters {
+		if waiter == t {
+			t.clock.waiters = append(t.clock.waiters[:i], t.clock.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
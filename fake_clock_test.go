@@ -0,0 +1,86 @@
+package sagas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewFakeClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		delays   []time.Duration
+		advance  time.Duration
+		stop     int
+		wantFire []bool
+	}{
+		{
+			name:     "[SUCCESS] Should fire the timers that are due once advanced",
+			delays:   []time.Duration{time.Second, time.Minute},
+			advance:  time.Second,
+			stop:     -1,
+			wantFire: []bool{true, false},
+		},
+
+		{
+			name:     "[SUCCESS] Should fire a timer whose duration is not positive right away",
+			delays:   []time.Duration{0},
+			advance:  0,
+			stop:     -1,
+			wantFire: []bool{true},
+		},
+
+		{
+			name:     "[FAILURE] Should not fire a stopped timer",
+			delays:   []time.Duration{time.Second},
+			advance:  time.Hour,
+			stop:     0,
+			wantFire: []bool{false},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			clock := NewFakeClock(start)
+
+			timers := make([]Timer, len(test.delays))
+			for i, delay := range test.delays {
+				timers[i] = clock.NewTimer(delay)
+			}
+			if test.stop >= 0 {
+				assert.True(t, timers[test.stop].Stop())
+			}
+			clock.Advance(test.advance)
+
+			assert.Equal(t, start.Add(test.advance), clock.Now())
+			for i, timer := range timers {
+				select {
+				case at := <-timer.C():
+					assert.True(t, test.wantFire[i])
+					assert.Equal(t, clock.Now(), at)
+				default:
+					assert.False(t, test.wantFire[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_fakeClock_Waiters(t *testing.T) {
+	t.Parallel()
+	clock := NewFakeClock(time.Time{})
+
+	after := clock.After(time.Minute)
+	assert.Equal(t, 1, clock.Waiters())
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, 0, clock.Waiters())
+	assert.Equal(t, time.Time{}.Add(time.Minute), <-after)
+}
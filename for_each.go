@@ -0,0 +1,256 @@
+package sagas
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+)
+
+// ErrForEachFailed is returned by a for-each step whose items did not all succeed.
+var ErrForEachFailed = errors.New("for each step failed")
+
+// ForEach describes the child steps that a for-each step creates at runtime, one for each item.
+type ForEach[T any] struct {
+	// Items returns the items of the child steps, such as the line items decoded from the output of a
+	// previous step.
+	Items func(context.Context) ([]T, error)
+	// Action is the action of the child step of each item.
+	Action func(context.Context, T) error
+	// Compensate undoes the action of an item whose child step succeeded, when another item fails. It is
+	// optional.
+	Compensate func(context.Context, T) error
+	// Key returns the key of an item, such as its SKU, which identifies its child step along with the index of
+	// the item, so the idempotency record of a child step is not found again for another item. It is optional;
+	// by default, the key is made from the JSON encoding of the item.
+	Key func(T) string
+	// Concurrency limits the number of child steps that run at once. They are not limited if it is not
+	// positive.
+	Concurrency int
+	// Options are the options of the child steps, such as their retrier.
+	Options []StepOption
+}
+
+// ItemResult is the result of the child step of an item.
+type ItemResult[T any] struct {
+	// Item is the item of the child step.
+	Item T
+	// Step is the identifier of the child step.
+	Step Identifier
+	// Status is the status of the child step. It is Undefined if the child step did not run.
+	Status Status
+	// Err is the error returned by the child step, if any.
+	Err error
+	// Compensated indicates whether the item was compensated.
+	Compensated bool
+}
+
+// ForEachStep is a Step that runs a child step for each item of a collection known at runtime.
+type ForEachStep[T any] interface {
+	Step
+	// Results returns the results of the child steps of the last execution of the step, in the order of the
+	// items.
+	Results() []ItemResult[T]
+}
+
+// forEachStep is the concrete implementation of the ForEachStep interface.
+type forEachStep[T any] struct {
+	*step
+	forEach ForEach[T]
+	results []ItemResult[T]
+	// resultsGuard is used to protect the results.
+	resultsGuard sync.Mutex
+}
+
+// NewForEachStep creates a new Step that runs a child step for each item returned by forEach.Items, at most
+// forEach.Concurrency at once. The step succeeds if every child step succeeds. Once a child step fails, no
+// other child step starts, the ones that are running are canceled and the ones that succeeded are compensated,
+// in the reverse order of their items; then the step fails with ErrForEachFailed. The child steps are
+// identified by the identifier of the step followed by the index and the key of their item, such as
+// "reserve items[0:sku-1]", and notify through the notifier of the step. The idempotency records of the
+// compensated child steps are replaced, so they run again when the step runs again. A panic will occur if the
+// name is empty or forEach.Items or forEach.Action are nil. Example:
+//
+//	reserveItems := sagas.NewForEachStep("reserve items", sagas.ForEach[LineItem]{
+//		Items:       func(ctx context.Context) ([]LineItem, error) { return decodeItems(order.Output()) },
+//		Action:      reserveItem,
+//		Compensate:  releaseItem,
+//		Key:         func(item LineItem) string { return item.SKU },
+//		Concurrency: 4,
+//	})
+//
+// The above example will reserve the line items of the order, four at a time, releasing the reserved ones if
+// any of them could not be reserved.
+func NewForEachStep[T any](name string, forEach ForEach[T], options ...StepOption) ForEachStep[T] {
+	if forEach.Items == nil {
+		panic(errors.New("items cannot be nil"))
+	}
+
+	if forEach.Action == nil {
+		panic(errors.New("action cannot be nil"))
+	}
+
+	s := &forEachStep[T]{
+		step:    NewStep(name, func(context.Context) error { return nil }, options...).(*step),
+		forEach: forEach,
+	}
+	s.action = NewAction(s.runItems)
+	return s
+}
+
+// Results returns a copy of the results of the child steps.
+func (s *forEachStep[T]) Results() []ItemResult[T] {
+	s.resultsGuard.Lock()
+	defer s.resultsGuard.Unlock()
+	return append([]ItemResult[T](nil), s.results...)
+}
+
+// runItems runs the child steps of the items and compensates the succeeded ones if any of them fails.
+func (s *forEachStep[T]) runItems(ctx context.Context) error {
+	items, err := s.forEach.Items(ctx)
+	if err != nil {
+		return err
+	}
+
+	results := make([]ItemResult[T], len(items))
+	for i, item := range items {
+		results[i] = ItemResult[T]{Item: item, Step: s.childIdentifier(i, item, "")}
+	}
+
+	failed := s.runChildren(ctx, results)
+	if err := ctx.Err(); err != nil {
+		s.setResults(results)
+		return err
+	}
+
+	if failed == nil {
+		s.setResults(results)
+		return nil
+	}
+
+	s.compensateChildren(ctx, results)
+	s.setResults(results)
+	return fmt.Errorf("%w: %s: %w", ErrForEachFailed, failed.Step, failed.Err)
+}
+
+// runChildren runs the child steps, at most forEach.Concurrency at once, until one of them fails. It returns
+// the result of the first child step that failed, if any.
+func (s *forEachStep[T]) runChildren(ctx context.Context, results []ItemResult[T]) *ItemResult[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	limit := s.forEach.Concurrency
+	if limit <= 0 || limit > len(results) {
+		limit = len(results)
+	}
+	slots := make(chan struct{}, max(limit, 1))
+
+	var failed *ItemResult[T]
+	var mutex sync.Mutex
+	wg := sync.WaitGroup{}
+	for i := range results {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(result *ItemResult[T]) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			child := s.child(result)
+			result.Err = child.Run(ctx)
+			result.Status = child.GetStatus()
+
+			if result.Status != Successed && result.Status != Skipped {
+				mutex.Lock()
+				if failed == nil {
+					failed = result
+				}
+				mutex.Unlock()
+				cancel()
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return failed
+}
+
+// child returns the child step of the item of the result.
+func (s *forEachStep[T]) child(result *ItemResult[T]) Step {
+	item := result.Item
+	options := append([]StepOption{WithStepNotifier(s.notfier)}, s.forEach.Options...)
+	options = append(options, WithStepIdentifier(result.Step))
+	return NewStep(result.Step.String(), func(ctx context.Context) error {
+		return s.forEach.Action(ctx, item)
+	}, options...)
+}
+
+// compensateChildren compensates the items whose child step succeeded, in the reverse order of the items.
+func (s *forEachStep[T]) compensateChildren(ctx context.Context, results []ItemResult[T]) {
+	if s.forEach.Compensate == nil {
+		return
+	}
+
+	for i := len(results) - 1; i >= 0; i-- {
+		if results[i].Status != Successed {
+			continue
+		}
+
+		item := results[i].Item
+		identifier := s.childIdentifier(i, item, " compensation")
+		compensation := NewStep(identifier.String(), func(ctx context.Context) error {
+			return s.forEach.Compensate(ctx, item)
+		}, WithStepNotifier(s.notfier), WithStepCompensation(), WithStepIdentifier(identifier))
+
+		results[i].Compensated = compensation.Run(ctx) == nil && compensation.GetStatus() == Successed
+		if !results[i].Compensated {
+			continue
+		}
+		if err := s.child(&results[i]).(*step).forget(ctx); err != nil {
+			logContext(withStepIdentifier(ctx, results[i].Step), slog.LevelError, "idempotency record not replaced",
+				slog.Any("error", err))
+		}
+	}
+}
+
+// childIdentifier returns the identifier of the child step of the item of the given index.
+func (s *forEachStep[T]) childIdentifier(index int, item T, suffix string) Identifier {
+	id := s.identifier.String() + "[" + strconv.Itoa(index)
+	if key := s.itemKey(item); key != "" {
+		id += ":" + key
+	}
+	return NewStableIdentifier(id + "]" + suffix)
+}
+
+// itemKey returns the key of the item, given by forEach.Key or made from the JSON encoding of the item. It is
+// empty if the item cannot be encoded.
+func (s *forEachStep[T]) itemKey(item T) string {
+	if s.forEach.Key != nil {
+		return s.forEach.Key(item)
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return ""
+	}
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])[0:12]
+}
+
+// setResults sets the results of the child steps.
+func (s *forEachStep[T]) setResults(results []ItemResult[T]) {
+	s.resultsGuard.Lock()
+	defer s.resultsGuard.Unlock()
+	s.results = results
+}
@@ -0,0 +1,221 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewForEachStep(t *testing.T) {
+	t.Parallel()
+
+	errOutOfStock := errors.New("out of stock")
+
+	tests := []struct {
+		name            string
+		items           []int
+		itemsErr        error
+		failing         int
+		concurrency     int
+		wantStatus      Status
+		wantErr         error
+		wantCompensated []int
+		wantMaxRunning  int32
+	}{
+		{
+			name:           "[SUCCESS] Should run every item within the concurrency limit",
+			items:          []int{1, 2, 3, 4, 5},
+			concurrency:    2,
+			wantStatus:     Successed,
+			wantMaxRunning: 2,
+		},
+
+		{
+			name:        "[SUCCESS] Should succeed without items",
+			items:       nil,
+			concurrency: 2,
+			wantStatus:  Successed,
+		},
+
+		{
+			name:            "[FAILURE] Should compensate the succeeded items when an item fails",
+			items:           []int{1, 2, 3},
+			failing:         3,
+			concurrency:     1,
+			wantStatus:      Failed,
+			wantErr:         errOutOfStock,
+			wantCompensated: []int{2, 1},
+			wantMaxRunning:  1,
+		},
+
+		{
+			name:       "[FAILURE] Should fail when the items cannot be listed",
+			itemsErr:   errOutOfStock,
+			wantStatus: Failed,
+			wantErr:    errOutOfStock,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var running, maxRunning atomic.Int32
+			var mutex sync.Mutex
+			var compensated []int
+
+			s := NewForEachStep("reserve items", ForEach[int]{
+				Items: func(context.Context) ([]int, error) { return test.items, test.itemsErr },
+				Action: func(_ context.Context, item int) error {
+					current := running.Add(1)
+					defer running.Add(-1)
+					for {
+						previous := maxRunning.Load()
+						if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					if item == test.failing {
+						return errOutOfStock
+					}
+					return nil
+				},
+				Compensate: func(_ context.Context, item int) error {
+					mutex.Lock()
+					defer mutex.Unlock()
+					compensated = append(compensated, item)
+					return nil
+				},
+				Concurrency: test.concurrency,
+			})
+
+			err := s.Run(context.Background())
+
+			assert.Equal(t, test.wantStatus, s.GetStatus())
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.wantCompensated, compensated)
+			assert.Equal(t, test.wantMaxRunning, maxRunning.Load())
+			assert.Len(t, s.Results(), len(test.items))
+		})
+	}
+}
+
+func Test_forEachStep_Results(t *testing.T) {
+	t.Parallel()
+	errOutOfStock := errors.New("out of stock")
+	s := NewForEachStep("reserve items", ForEach[string]{
+		Items: func(context.Context) ([]string, error) { return []string{"book", "pen", "ink"}, nil },
+		Action: func(_ context.Context, item string) error {
+			if item == "pen" {
+				return errOutOfStock
+			}
+			return nil
+		},
+		Compensate:  func(context.Context, string) error { return nil },
+		Key:         func(item string) string { return item },
+		Concurrency: 1,
+	}, WithStepIdentifier(NewStableIdentifier("reserve")))
+
+	err := s.Run(context.Background())
+
+	assert.ErrorIs(t, err, ErrForEachFailed)
+	assert.Equal(t, []ItemResult[string]{
+		{Item: "book", Step: NewStableIdentifier("reserve[0:book]"), Status: Successed, Compensated: true},
+		{Item: "pen", Step: NewStableIdentifier("reserve[1:pen]"), Status: Failed, Err: errOutOfStock},
+		{Item: "ink", Step: NewStableIdentifier("reserve[2:ink]"), Status: Undefined},
+	}, s.Results())
+}
+
+func Test_forEachStep_childIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		items     []string
+		wantEqual bool
+	}{
+		{
+			name:      "[SUCCESS] Should give the same identifier to the same item at the same index",
+			items:     []string{"book", "book"},
+			wantEqual: true,
+		},
+
+		{
+			name:      "[SUCCESS] Should give another identifier to another item at the same index",
+			items:     []string{"book", "pen"},
+			wantEqual: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			s := NewForEachStep("reserve", ForEach[string]{
+				Items:  func(context.Context) ([]string, error) { return nil, nil },
+				Action: func(context.Context, string) error { return nil },
+			}, WithStepIdentifier(NewStableIdentifier("reserve"))).(*forEachStep[string])
+
+			first, second := s.childIdentifier(0, test.items[0], ""), s.childIdentifier(0, test.items[1], "")
+
+			assert.Equal(t, test.wantEqual, first == second)
+			assert.True(t, strings.HasPrefix(first.String(), "reserve[0:"))
+		})
+	}
+}
+
+func Test_forEachStep_Run_WithIdempotencyStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should run again the compensated items when the step runs again", func(t *testing.T) {
+		t.Parallel()
+		store := NewMemoryIdempotencyStore()
+		var mutex sync.Mutex
+		reserved := make(map[string]int)
+		failing := "ink"
+		s := NewForEachStep("reserve", ForEach[string]{
+			Items: func(context.Context) ([]string, error) { return []string{"book", "pen", "ink"}, nil },
+			Action: func(_ context.Context, item string) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				if item == failing {
+					return errors.New("out of stock")
+				}
+				reserved[item]++
+				return nil
+			},
+			Compensate:  func(context.Context, string) error { return nil },
+			Concurrency: 1,
+		}, WithStepIdentifier(NewStableIdentifier("reserve")))
+		ctx := withIdempotencyStore(withSagaIdentifier(context.Background(), NewStableIdentifier("order-42")), store)
+
+		assert.ErrorIs(t, s.Run(ctx), ErrForEachFailed)
+		mutex.Lock()
+		failing = ""
+		mutex.Unlock()
+		assert.NoError(t, s.Run(ctx))
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		assert.Equal(t, map[string]int{"book": 2, "pen": 2, "ink": 1}, reserved)
+	})
+}
+
+func Test_NewForEachStep_Panic(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { NewForEachStep("items", ForEach[int]{}) })
+	assert.Panics(t, func() {
+		NewForEachStep("items", ForEach[int]{Items: func(context.Context) ([]int, error) { return nil, nil }})
+	})
+}
@@ -0,0 +1,113 @@
+package sagas
+
+import (
+	"context"
+	"time"
+)
+
+// SagaInfo describes a saga instance to the lifecycle hooks.
+type SagaInfo struct {
+	// Saga is the identifier of the saga instance.
+	Saga Identifier
+	// StartedAt is the time the saga started.
+	StartedAt time.Time
+	// Duration is the time the saga took. It is zero when the saga starts.
+	Duration time.Duration
+	// Err is the error of the context that stopped the saga, if it did not end by itself.
+	Err error
+}
+
+// StepInfo describes a step of a saga instance to the lifecycle hooks.
+type StepInfo struct {
+	// Saga is the identifier of the saga instance. It is nil if the step runs on its own.
+	Saga Identifier
+	// Step is the identifier of the step.
+	Step Identifier
+	// Compensation indicates whether the step compensates other steps.
+	Compensation bool
+	// Attempt is the number of attempts performed by the step. It is zero when the step starts.
+	Attempt int
+	// Status is the status of the step. It is Undefined when the step starts.
+	Status Status
+	// Err is the error returned by the step, if any.
+	Err error
+	// Duration is the time the step took. It is zero when the step starts.
+	Duration time.Duration
+}
+
+// Hooks are the functions called synchronously along the lifecycle of a saga instance. Every hook is optional.
+type Hooks struct {
+	// OnStart is called when the saga starts, before its first step runs.
+	OnStart func(context.Context, SagaInfo)
+	// OnStepStart is called when a step starts, before its action runs.
+	OnStepStart func(context.Context, StepInfo)
+	// OnStepEnd is called when a step ends, with its status, error, attempts and duration.
+	OnStepEnd func(context.Context, StepInfo)
+	// OnCompensate is called when a compensation step starts, right after OnStepStart.
+	OnCompensate func(context.Context, StepInfo)
+	// OnFinish is called when the saga ends, with its duration.
+	OnFinish func(context.Context, SagaInfo)
+}
+
+// hookList is the list of hooks of a saga, called in the order they were registered.
+type hookList []Hooks
+
+// start calls the OnStart hooks.
+func (l hookList) start(ctx context.Context, info SagaInfo) {
+	for _, h := range l {
+		if h.OnStart != nil {
+			h.OnStart(ctx, info)
+		}
+	}
+}
+
+// stepStart calls the OnStepStart hooks and, for a compensation step, the OnCompensate hooks.
+func (l hookList) stepStart(ctx context.Context, info StepInfo) {
+	for _, h := range l {
+		if h.OnStepStart != nil {
+			h.OnStepStart(ctx, info)
+		}
+	}
+
+	if !info.Compensation {
+		return
+	}
+
+	for _, h := range l {
+		if h.OnCompensate != nil {
+			h.OnCompensate(ctx, info)
+		}
+	}
+}
+
+// stepEnd calls the OnStepEnd hooks.
+func (l hookList) stepEnd(ctx context.Context, info StepInfo) {
+	for _, h := range l {
+		if h.OnStepEnd != nil {
+			h.OnStepEnd(ctx, info)
+		}
+	}
+}
+
+// finish calls the OnFinish hooks.
+func (l hookList) finish(ctx context.Context, info SagaInfo) {
+	for _, h := range l {
+		if h.OnFinish != nil {
+			h.OnFinish(ctx, info)
+		}
+	}
+}
+
+// hooksKey is the context key of the hooks of the saga.
+type hooksKey struct{}
+
+// withHooks returns a copy of the context that carries the given hooks.
+func withHooks(ctx context.Context, hooks hookList) context.Context {
+	return context.WithValue(ctx, hooksKey{}, hooks)
+}
+
+// hooksFromContext returns the hooks carried by the context, if any.
+func hooksFromContext(ctx context.Context) hookList {
+	hooks, _ := ctx.Value(hooksKey{}).(hookList)
+	return hooks
+}
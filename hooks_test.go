@@ -0,0 +1,116 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_saga_Run_WithHooks(t *testing.T) {
+	t.Parallel()
+
+	errDeclined := errors.New("declined")
+
+	tests := []struct {
+		name      string
+		ctx       func() (context.Context, context.CancelFunc)
+		chargeErr error
+		wantCalls []string
+		wantErr   error
+	}{
+		{
+			name:      "[SUCCESS] Should call the hooks along the saga",
+			ctx:       func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			chargeErr: nil,
+			wantCalls: []string{
+				"start", "step start reserve", "step end reserve Successed 1 <nil>",
+				"step start charge", "step end charge Successed 1 <nil>", "finish <nil>",
+			},
+		},
+
+		{
+			name:      "[SUCCESS] Should call the compensation hooks when a step fails",
+			ctx:       func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			chargeErr: errDeclined,
+			wantCalls: []string{
+				"start", "step start reserve", "step end reserve Successed 1 <nil>",
+				"step start charge", "step end charge Failed 1 declined",
+				"step start release", "compensate release", "step end release Successed 1 <nil>", "finish <nil>",
+			},
+		},
+
+		{
+			name: "[FAILURE] Should finish with the error of the context",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			wantCalls: []string{"start", "step start reserve", "step end reserve Successed 1 <nil>", "finish context canceled"},
+			wantErr:   context.Canceled,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var mutex sync.Mutex
+			var calls []string
+			var finished SagaInfo
+			call := func(format string, args ...any) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				calls = append(calls, fmt.Sprintf(format, args...))
+			}
+
+			hooks := Hooks{
+				OnStart: func(_ context.Context, info SagaInfo) { call("start") },
+				OnStepStart: func(_ context.Context, info StepInfo) {
+					call("step start %s", IdentifierName(info.Step))
+				},
+				OnStepEnd: func(_ context.Context, info StepInfo) {
+					call("step end %s %s %d %v", IdentifierName(info.Step), info.Status, info.Attempt, info.Err)
+				},
+				OnCompensate: func(_ context.Context, info StepInfo) {
+					call("compensate %s", IdentifierName(info.Step))
+				},
+				OnFinish: func(_ context.Context, info SagaInfo) {
+					finished = info
+					call("finish %v", info.Err)
+				},
+			}
+
+			reserve := NewStep("reserve", func(context.Context) error { return nil })
+			charge := NewStep("charge", func(context.Context) error { return test.chargeErr })
+			release := NewStep("release", func(context.Context) error { return nil }, WithStepCompensation())
+			c := NewSaga(WithSagaHooks(hooks))
+			c.AddSteps(reserve, charge, release)
+
+			ctx, cancel := test.ctx()
+			defer cancel()
+			if test.wantErr != nil {
+				c.Run(ctx, func() bool { return false })
+			} else {
+				c.When(reserve).Is(Successed).Then(NewAction(charge.Run)).Plan()
+				c.When(charge).Is(Failed).Then(NewAction(release.Run)).Plan()
+				c.Run(ctx, func() bool {
+					return charge.GetStatus() == Successed && charge.GetState() == Completed ||
+						release.GetState() == Completed
+				})
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			assert.Equal(t, test.wantCalls, calls)
+			assert.Equal(t, c.GetIdentifier(), finished.Saga)
+			assert.ErrorIs(t, finished.Err, test.wantErr)
+			assert.GreaterOrEqual(t, finished.Duration, time.Duration(0))
+		})
+	}
+}
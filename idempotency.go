@@ -0,0 +1,84 @@
+package sagas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// IdempotencyRecord is the result of a step execution kept by an IdempotencyStore.
+type IdempotencyRecord struct {
+	// Status is the final status of the step.
+	Status Status
+	// Output is the output set by the action of the step with SetOutput.
+	Output []byte
+	// CompletedAt is the time the step completed.
+	CompletedAt time.Time
+}
+
+// IdempotencyStore is the interface that wraps the methods to keep the results of the steps by their
+// idempotency keys. The engine consults it before running a step, so a step already recorded as Successed, after
+// a crash and resume or a duplicate trigger, is not run again and its stored output is restored instead.
+type IdempotencyStore interface {
+	// Load returns the record of the key. The returned boolean is false if there is no record of the key.
+	Load(ctx context.Context, key string) (IdempotencyRecord, bool, error)
+	// Save stores the record of the key, replacing any previous one.
+	Save(ctx context.Context, key string, record IdempotencyRecord) error
+}
+
+// IdempotencyKey returns the idempotency key of a step of a saga instance. The key is stable as long as both
+// identifiers are, so sagas that are resumed must be given a stable identifier with WithSagaIdentifier and their
+// steps one with WithStepIdentifier(NewStableIdentifier(...)). The default identifier of a step has a suffix
+// that changes in every process, so its key does not match after a crash and resume. The saga can be nil for
+// steps that run on their own. Example:
+//
+//	key := sagas.IdempotencyKey(saga.GetIdentifier(), step.GetIdentifier())
+//
+// The above example will return the key given to the attempts of the step in the saga.
+func IdempotencyKey(saga Identifier, step Identifier) string {
+	h := sha256.New()
+	if saga != nil {
+		h.Write([]byte(saga.String()))
+	}
+	h.Write([]byte{0})
+	h.Write([]byte(step.String()))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyKeyKey is the context key of the idempotency key of the running step.
+type idempotencyKeyKey struct{}
+
+// withIdempotencyKey returns a copy of the context that carries the idempotency key of the running step.
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key of the step whose action is running with the given
+// context. The key is the same for every attempt of the step in a saga instance, so it can be handed to the
+// downstream services to deduplicate the requests. Example:
+//
+//	step := sagas.NewStep("charge", func(ctx context.Context) error {
+//		key, _ := sagas.IdempotencyKeyFromContext(ctx)
+//		return payments.Charge(ctx, order, key)
+//	})
+//
+// The above example will charge the order with the idempotency key of the step.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyKey{}).(string)
+	return key, ok
+}
+
+// idempotencyStoreKey is the context key of the IdempotencyStore of the saga.
+type idempotencyStoreKey struct{}
+
+// withIdempotencyStore returns a copy of the context that carries the given IdempotencyStore.
+func withIdempotencyStore(ctx context.Context, store IdempotencyStore) context.Context {
+	return context.WithValue(ctx, idempotencyStoreKey{}, store)
+}
+
+// idempotencyStoreFromContext returns the IdempotencyStore carried by the context, if any.
+func idempotencyStoreFromContext(ctx context.Context) (IdempotencyStore, bool) {
+	store, ok := ctx.Value(idempotencyStoreKey{}).(IdempotencyStore)
+	return store, ok && store != nil
+}
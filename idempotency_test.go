@@ -0,0 +1,296 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	saga, step := NewStableIdentifier("saga"), NewStableIdentifier("step")
+
+	tests := []struct {
+		name  string
+		saga  Identifier
+		step  Identifier
+		other string
+		equal bool
+	}{
+		{
+			name:  "[SUCCESS] Should be stable for the same saga and step",
+			saga:  saga,
+			step:  step,
+			other: IdempotencyKey(NewStableIdentifier("saga"), NewStableIdentifier("step")),
+			equal: true,
+		},
+
+		{
+			name:  "[SUCCESS] Should differ for another saga instance",
+			saga:  saga,
+			step:  step,
+			other: IdempotencyKey(NewStableIdentifier("saga2"), step),
+			equal: false,
+		},
+
+		{
+			name:  "[SUCCESS] Should not be ambiguous on the boundary of the identifiers",
+			saga:  NewStableIdentifier("ab"),
+			step:  NewStableIdentifier("c"),
+			other: IdempotencyKey(NewStableIdentifier("a"), NewStableIdentifier("bc")),
+			equal: false,
+		},
+
+		{
+			name:  "[SUCCESS] Should accept a step without saga",
+			saga:  nil,
+			step:  step,
+			other: IdempotencyKey(nil, step),
+			equal: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := IdempotencyKey(test.saga, test.step)
+			assert.Len(t, got, 64)
+			assert.Equal(t, test.equal, got == test.other)
+		})
+	}
+}
+
+func Test_step_Run_IdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should give the same key to every attempt of the step", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			saga := NewStableIdentifier("saga")
+			keys := []string{}
+			s := NewStep("test", func(ctx context.Context) error {
+				key, ok := IdempotencyKeyFromContext(ctx)
+				assert.True(t, ok)
+				keys = append(keys, key)
+				if len(keys) == 1 {
+					return assert.AnError
+				}
+				return nil
+			}, WithStepRetrier(NewRetrier(BackoffConstant(1, 1*time.Millisecond))))
+
+			err := s.Run(withSagaIdentifier(context.Background(), saga))
+
+			assert.NoError(t, err)
+			want := IdempotencyKey(saga, s.GetIdentifier())
+			assert.Equal(t, []string{want, want}, keys)
+		})
+	})
+}
+
+func Test_step_Run_WithIdempotencyStore(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		err     error
+		options func(store IdempotencyStore) []StepOption
+		ctx     func(store IdempotencyStore) context.Context
+	}
+
+	withStep := func(store IdempotencyStore) []StepOption {
+		return []StepOption{WithStepIdempotencyStore(store)}
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantCalls  int
+		wantStatus Status
+		wantOutput []byte
+	}{
+		{
+			name:       "[SUCCESS] Should not run again a succeeded step",
+			args:       args{options: withStep},
+			wantCalls:  1,
+			wantStatus: Successed,
+			wantOutput: []byte("receipt"),
+		},
+
+		{
+			name: "[SUCCESS] Should use the store of the saga carried by the context",
+			args: args{
+				ctx: func(store IdempotencyStore) context.Context {
+					return withIdempotencyStore(context.Background(), store)
+				},
+			},
+			wantCalls:  1,
+			wantStatus: Successed,
+			wantOutput: []byte("receipt"),
+		},
+
+		{
+			name:       "[FAILURE] Should run again a failed step",
+			args:       args{err: assert.AnError, options: withStep},
+			wantCalls:  2,
+			wantStatus: Failed,
+			wantOutput: []byte("receipt"),
+		},
+
+		{
+			name:       "[SUCCESS] Should run again without a store",
+			args:       args{},
+			wantCalls:  2,
+			wantStatus: Successed,
+			wantOutput: []byte("receipt"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				store := NewMemoryIdempotencyStore()
+				options := []StepOption{WithStepIdentifier(NewStableIdentifier("charge"))}
+				if test.args.options != nil {
+					options = append(options, test.args.options(store)...)
+				}
+				ctx := context.Background()
+				if test.args.ctx != nil {
+					ctx = test.args.ctx(store)
+				}
+
+				calls := 0
+				action := func(ctx context.Context) error {
+					calls++
+					SetOutput(ctx, []byte("receipt"))
+					return test.args.err
+				}
+
+				_ = NewStep("charge", action, options...).Run(ctx)
+				again := NewStep("charge", action, options...)
+				_ = again.Run(ctx)
+
+				assert.Equal(t, test.wantCalls, calls)
+				assert.Equal(t, test.wantStatus, again.GetStatus())
+				assert.Equal(t, test.wantOutput, again.Output())
+			})
+		})
+	}
+}
+
+func Test_saga_Run_WithIdempotencyStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should not run again the steps of a resumed saga instance", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			store := NewMemoryIdempotencyStore()
+			calls := 0
+			run := func() {
+				starter := NewStep("charge", func(ctx context.Context) error {
+					calls++
+					return nil
+				}, WithStepIdentifier(NewStableIdentifier("charge")))
+				c := NewSaga(WithSagaIdentifier(NewStableIdentifier("order-42")), WithSagaIdempotencyStore(store))
+				c.AddSteps(starter)
+				c.Run(context.Background(), func() bool { return starter.GetState() == Completed })
+				assert.Equal(t, Successed, starter.GetStatus())
+			}
+
+			run()
+			run()
+
+			assert.Equal(t, 1, calls)
+		})
+	})
+
+	t.Run("[SUCCESS] Should run the steps of every saga instance created with the same name and clock", func(t *testing.T) {
+		t.Parallel()
+		store := NewMemoryIdempotencyStore()
+		clock := NewFakeClock(time.Unix(0, 0))
+		var charges atomic.Int32
+		run := func() {
+			charge := NewStep("charge", func(context.Context) error {
+				charges.Add(1)
+				return nil
+			}, WithStepIdentifier(NewStableIdentifier("charge")))
+			c := NewSaga(WithSagaName("checkout"), WithSagaClock(clock), WithSagaIdempotencyStore(store))
+			c.AddSteps(charge)
+			c.Run(context.Background(), func() bool { return charge.GetState() == Completed })
+			assert.Equal(t, Successed, charge.GetStatus())
+		}
+
+		run()
+		run()
+
+		assert.Equal(t, int32(2), charges.Load())
+	})
+}
+
+func Test_saga_Run_WithIdempotencyStore_Resume(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		options   func(name string, startedAt time.Time) []StepOption
+		wantCalls int32
+	}{
+		{
+			name: "[SUCCESS] Should not run again the steps with stable identifiers after a crash",
+			options: func(name string, _ time.Time) []StepOption {
+				return []StepOption{WithStepIdentifier(NewStableIdentifier(name))}
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "[SUCCESS] Should run again the steps with default identifiers after a crash",
+			options: func(name string, startedAt time.Time) []StepOption {
+				// The default identifier of a step is made from the time the process builds it.
+				return []StepOption{WithStepIdentifier(NewIdentifierWithClock(name, NewFakeClock(startedAt)))}
+			},
+			wantCalls: 2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			store := NewMemoryIdempotencyStore()
+			var charges atomic.Int32
+
+			// process builds the saga and its steps from scratch, as a new process started at the given time does,
+			// and runs it until the shipment ends, failing it to simulate a crash.
+			process := func(startedAt time.Time, crash bool) Step {
+				charge := NewStep("charge", func(context.Context) error {
+					charges.Add(1)
+					return nil
+				}, test.options("charge", startedAt)...)
+				ship := NewStep("ship", func(context.Context) error {
+					if crash {
+						return errors.New("crashed")
+					}
+					return nil
+				}, append(test.options("ship", startedAt), WithStepRetrier(NewRetrier(BackoffConstant(1, time.Millisecond))))...)
+
+				c := NewSaga(WithSagaIdentifier(NewStableIdentifier("order-42")), WithSagaIdempotencyStore(store))
+				c.AddSteps(charge, ship)
+				c.When(charge).Is(Successed).Then(NewAction(ship.Run)).Plan()
+				c.Run(context.Background(), func() bool { return ship.GetState() == Completed })
+				return ship
+			}
+
+			startedAt := time.Unix(0, 0)
+			assert.Equal(t, Failed, process(startedAt, true).GetStatus())
+			assert.Equal(t, Successed, process(startedAt.Add(time.Minute), false).GetStatus())
+
+			assert.Equal(t, test.wantCalls, charges.Load())
+		})
+	}
+}
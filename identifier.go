@@ -1,8 +1,12 @@
 package sagas
 
 import (
+	"crypto/rand"
 	"crypto/sha1"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
 	"time"
 )
 
@@ -23,20 +27,120 @@ type identifier string
 //
 //	identifier := sagas.NewIdentifier("step")
 //
-// The identifier will be a string in the format "step:unique_identifier".
+// The identifier will be a string in the format "step:unique_identifier", where the unique identifier is
+// random, so that two identifiers created with the same name at the same time differ.
 func NewIdentifier(name string) Identifier {
 	return identifier(name + ":" + makeUniqueIdentifier(name)[0:12])
 }
 
+// NewIdentifierWithClock is a function that creates a new identifier whose unique
+// suffix is made from the time of the given clock instead of the time of the system,
+// so that the identifier is the same on every run of a test. Example:
+//
+//	identifier := sagas.NewIdentifierWithClock("step", sagas.NewFakeClock(time.Unix(0, 0)))
+//
+// The identifier will be a string in the format "step:unique_identifier".
+func NewIdentifierWithClock(name string, clock Clock) Identifier {
+	return identifier(name + ":" + makeUniqueIdentifierAt(name, clock.Now())[0:12])
+}
+
+// NewStableIdentifier is a function that creates an identifier that is exactly the
+// given name, without a unique suffix. It is used to identify a step across services,
+// so that they agree on its identifier. Example:
+//
+//	identifier := sagas.NewStableIdentifier("payments.charge")
+//
+// The identifier will be the string "payments.charge".
+func NewStableIdentifier(name string) Identifier {
+	return identifier(name)
+}
+
 // String is a method that returns the string representation of the identifier.
 func (i identifier) String() string {
 	return string(i)
 }
 
-// MakeUniqueIdentifier returns a unique identifier for a given string.
+// ParseIdentifier is a function that returns the identifier whose string representation
+// is the given string, as returned by the String method. It returns an error if the string
+// is empty. Example:
+//
+//	identifier, err := sagas.ParseIdentifier(step.GetIdentifier().String())
+//
+// The identifier will be equal to the identifier of the step.
+func ParseIdentifier(s string) (Identifier, error) {
+	if s == "" {
+		return nil, errors.New("invalid identifier")
+	}
+	return identifier(s), nil
+}
+
+// MarshalText returns the string representation of the identifier.
+func (i identifier) MarshalText() ([]byte, error) {
+	if i == "" {
+		return nil, errors.New("invalid identifier")
+	}
+	return []byte(i), nil
+}
+
+// UnmarshalText parses the string representation of an identifier.
+func (i *identifier) UnmarshalText(text []byte) error {
+	id, err := ParseIdentifier(string(text))
+	if err != nil {
+		return err
+	}
+	*i = id.(identifier)
+	return nil
+}
+
+// MarshalJSON returns the string representation of the identifier as a JSON string.
+func (i identifier) MarshalJSON() ([]byte, error) {
+	text, err := i.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON parses the string representation of an identifier from a JSON string.
+func (i *identifier) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return i.UnmarshalText([]byte(s))
+}
+
+// makeUniqueIdentifier returns a random unique identifier for a given string.
 func makeUniqueIdentifier(s string) string {
+	salt := make([]byte, 16)
+	_, _ = rand.Read(salt)
+	return makeUniqueIdentifierAt(hex.EncodeToString(salt)+s, time.Now())
+}
+
+// makeUniqueIdentifierAt returns a unique identifier for a given string at the given time.
+func makeUniqueIdentifierAt(s string, now time.Time) string {
 	h := sha1.New()
-	s = time.Now().Format(time.RFC850) + s
+	s = now.Format(time.RFC850) + s
 	h.Write([]byte(s))
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// IdentifierName returns the name an identifier was created with, without the unique suffix added by
+// NewIdentifier, so that every instance of a step or saga shares the same name. An identifier without such
+// a suffix, such as a stable identifier, is returned as it is. Example:
+//
+//	name := sagas.IdentifierName(sagas.NewIdentifier("orders:charge"))
+//
+// The name will be "orders:charge".
+func IdentifierName(id Identifier) string {
+	if id == nil {
+		return ""
+	}
+
+	s := id.String()
+	i := strings.LastIndex(s, ":")
+	if i < 0 || len(s)-i-1 != 12 || strings.Trim(s[i+1:], "0123456789abcdef") != "" {
+		return s
+	}
+	return s[:i]
+}
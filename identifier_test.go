@@ -1,7 +1,10 @@
 package sagas
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -53,3 +56,130 @@ func Test_makeUniqueIdentifier(t *testing.T) {
 	})
 
 }
+
+func Test_NewIdentifierWithClock(t *testing.T) {
+	t.Parallel()
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	got := NewIdentifierWithClock("step", clock)
+
+	assert.Equal(t, got, NewIdentifierWithClock("step", clock))
+	assert.True(t, strings.HasPrefix(got.String(), "step:"))
+	assert.Len(t, got.String(), len("step:")+12)
+}
+
+func Test_IdentifierName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		id   Identifier
+		want string
+	}{
+		{
+			name: "[SUCCESS] Should return the name without the unique suffix",
+			id:   NewIdentifier("payment"),
+			want: "payment",
+		},
+
+		{
+			name: "[SUCCESS] Should keep the colons of the name",
+			id:   NewIdentifier("orders:charge"),
+			want: "orders:charge",
+		},
+
+		{
+			name: "[SUCCESS] Should keep a stable identifier",
+			id:   NewStableIdentifier("payments:charge"),
+			want: "payments:charge",
+		},
+
+		{
+			name: "[SUCCESS] Should return an empty name for a nil identifier",
+			id:   nil,
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, IdentifierName(test.id))
+		})
+	}
+}
+
+func Test_NewStableIdentifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should return the name without a unique suffix", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			got := NewStableIdentifier("payments.charge")
+			assert.Equal(t, "payments.charge", got.String())
+			assert.Equal(t, NewStableIdentifier("payments.charge"), got)
+		})
+	})
+}
+
+func Test_ParseIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		wantErr bool
+	}{
+		{
+			name: "[SUCCESS] Should parse the string of an identifier",
+			s:    NewIdentifier("step").String(),
+		},
+
+		{
+			name:    "[FAILURE] Should not parse an empty string",
+			s:       "",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseIdentifier(test.s)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, identifier(test.s), got)
+		})
+	}
+}
+
+func Test_identifier_JSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should round trip an identifier", func(t *testing.T) {
+		t.Parallel()
+		id := NewIdentifier("step")
+
+		data, err := json.Marshal(id)
+		assert.NoError(t, err)
+		assert.Equal(t, `"`+id.String()+`"`, string(data))
+
+		var got identifier
+		assert.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, id, Identifier(got))
+	})
+
+	t.Run("[FAILURE] Should not round trip an empty identifier", func(t *testing.T) {
+		t.Parallel()
+		_, err := json.Marshal(identifier(""))
+		assert.Error(t, err)
+
+		var got identifier
+		assert.Error(t, json.Unmarshal([]byte(`""`), &got))
+	})
+}
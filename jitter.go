@@ -0,0 +1,54 @@
+package sagas
+
+import "time"
+
+// Jitter is a function that randomizes a back-off delay. It receives the delay computed by the Backoff and a
+// random number in the range [0.0,1.0), and returns the delay that will be waited before the next retry.
+type Jitter func(delay time.Duration, random float64) time.Duration
+
+// JitterNone returns a Jitter that does not randomize the delay. Example:
+//
+//	retrier := sagas.NewRetrier(sagas.BackoffConstant(3, 1*time.Second), sagas.WithRetrierJitter(sagas.JitterNone()))
+//
+// The above example creates a Retrier that waits exactly 1 second between each retry.
+func JitterNone() Jitter {
+	return func(delay time.Duration, _ float64) time.Duration {
+		return delay
+	}
+}
+
+// JitterFull returns a Jitter that randomizes the delay in the range [0, delay). Example:
+//
+//	retrier := sagas.NewRetrier(sagas.BackoffConstant(3, 1*time.Second), sagas.WithRetrierJitter(sagas.JitterFull()))
+//
+// The above example creates a Retrier that waits between 0 and 1 second between each retry.
+func JitterFull() Jitter {
+	return func(delay time.Duration, random float64) time.Duration {
+		return time.Duration(random * float64(delay))
+	}
+}
+
+// JitterEqual returns a Jitter that keeps half of the delay and randomizes the other half, resulting in the
+// range [delay/2, delay). Example:
+//
+//	retrier := sagas.NewRetrier(sagas.BackoffConstant(3, 1*time.Second), sagas.WithRetrierJitter(sagas.JitterEqual()))
+//
+// The above example creates a Retrier that waits between 0.5 and 1 second between each retry.
+func JitterEqual() Jitter {
+	return func(delay time.Duration, random float64) time.Duration {
+		return delay/2 + time.Duration(random*float64(delay/2))
+	}
+}
+
+// JitterProportional returns a Jitter that randomizes the delay by up to factor times the delay in both
+// directions, resulting in the range [delay-factor*delay, delay+factor*delay). A factor of 1 is the jitter
+// applied by NewRetrier. Example:
+//
+//	retrier := sagas.NewRetrier(sagas.BackoffConstant(3, 1*time.Second), sagas.WithRetrierJitter(sagas.JitterProportional(0.1)))
+//
+// The above example creates a Retrier that waits between 0.9 and 1.1 second between each retry.
+func JitterProportional(factor float64) Jitter {
+	return func(delay time.Duration, random float64) time.Duration {
+		return delay + time.Duration(((random*2)-1)*factor*float64(delay))
+	}
+}
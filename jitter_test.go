@@ -0,0 +1,75 @@
+package sagas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Jitter(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		jitter Jitter
+		delay  time.Duration
+		random float64
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want time.Duration
+	}{
+		{
+			name: "[SUCCESS] Should not randomize the delay with JitterNone",
+			args: args{
+				jitter: JitterNone(),
+				delay:  1 * time.Second,
+				random: 0.25,
+			},
+			want: 1 * time.Second,
+		},
+
+		{
+			name: "[SUCCESS] Should randomize the whole delay with JitterFull",
+			args: args{
+				jitter: JitterFull(),
+				delay:  1 * time.Second,
+				random: 0.25,
+			},
+			want: 250 * time.Millisecond,
+		},
+
+		{
+			name: "[SUCCESS] Should randomize half of the delay with JitterEqual",
+			args: args{
+				jitter: JitterEqual(),
+				delay:  1 * time.Second,
+				random: 0.25,
+			},
+			want: 625 * time.Millisecond,
+		},
+
+		{
+			name: "[SUCCESS] Should randomize the delay in both directions with JitterProportional",
+			args: args{
+				jitter: JitterProportional(0.5),
+				delay:  1 * time.Second,
+				random: 0.25,
+			},
+			want: 750 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				got := test.args.jitter(test.args.delay, test.args.random)
+				assert.Equal(t, test.want, got)
+			})
+		})
+	}
+}
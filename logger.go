@@ -0,0 +1,91 @@
+package sagas
+
+import (
+	"context"
+	"log/slog"
+)
+
+// The keys of the attributes that every log line of the engine carries, when they are known.
+const (
+	// LogKeySagaID is the key of the identifier of the saga instance.
+	LogKeySagaID = "saga_id"
+	// LogKeyStepID is the key of the identifier of the step.
+	LogKeyStepID = "step_id"
+	// LogKeyEvent is the key of the event that triggered the action.
+	LogKeyEvent = "event"
+	// LogKeyAttempt is the key of the number of the attempt.
+	LogKeyAttempt = "attempt"
+)
+
+// discardHandler is a slog.Handler that discards every record. It backs the logger used when no logger is
+// provided.
+type discardHandler struct{}
+
+// Enabled always returns false, so the records are never built.
+func (discardHandler) Enabled(context.Context, slog.Level) bool { return false }
+
+// Handle does nothing.
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+
+// WithAttrs returns the handler itself.
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+// WithGroup returns the handler itself.
+func (h discardHandler) WithGroup(string) slog.Handler { return h }
+
+// discardLogger is the logger used when no logger is provided. It does not log anything.
+var discardLogger = slog.New(discardHandler{})
+
+// loggerKey is the context key of the logger.
+type loggerKey struct{}
+
+// withLogger returns a copy of the context that carries the given logger.
+func withLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// loggerFromContext returns the logger carried by the context. If there is none, it returns a logger that does
+// not log anything.
+func loggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	return discardLogger
+}
+
+// logContext logs the message with the logger carried by the context. The record carries the identifiers of
+// the saga and the step, the event and the attempt found in the context, followed by the given arguments.
+func logContext(ctx context.Context, level slog.Level, msg string, args ...any) {
+	logger := loggerFromContext(ctx)
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	logger.Log(ctx, level, msg, append(contextAttrs(ctx), args...)...)
+}
+
+// contextAttrs returns the log attributes of the saga, the step, the event and the attempt carried by the
+// context. When the context carries no step, the identifier of the notification is used instead.
+func contextAttrs(ctx context.Context) []any {
+	attrs := make([]any, 0, 4)
+
+	if saga, ok := sagaIdentifierFromContext(ctx); ok {
+		attrs = append(attrs, slog.String(LogKeySagaID, saga.String()))
+	}
+
+	notification, hasNotification := notificationFromContext(ctx)
+	if step, ok := stepIdentifierFromContext(ctx); ok {
+		attrs = append(attrs, slog.String(LogKeyStepID, step.String()))
+	} else if hasNotification && notification.Identifier != nil {
+		attrs = append(attrs, slog.String(LogKeyStepID, notification.Identifier.String()))
+	}
+
+	if hasNotification && notification.Event != nil {
+		attrs = append(attrs, slog.String(LogKeyEvent, notification.Event.String()))
+	}
+
+	if attempt, ok := attemptNumberFromContext(ctx); ok {
+		attrs = append(attrs, slog.Int(LogKeyAttempt, attempt))
+	}
+
+	return attrs
+}
@@ -0,0 +1,239 @@
+package sagas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type logBuffer struct {
+	buffer bytes.Buffer
+	mutex  sync.Mutex
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *logBuffer) records() []map[string]any {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	records := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimSpace(b.buffer.String()), "\n") {
+		record := map[string]any{}
+		if json.Unmarshal([]byte(line), &record) == nil {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func (b *logBuffer) find(msg string) map[string]any {
+	for _, record := range b.records() {
+		if record["msg"] == msg {
+			return record
+		}
+	}
+	return nil
+}
+
+func newBufferLogger() (*slog.Logger, *logBuffer) {
+	buffer := &logBuffer{}
+	return slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug})), buffer
+}
+
+func Test_loggerFromContext(t *testing.T) {
+	t.Parallel()
+
+	logger, _ := newBufferLogger()
+
+	tests := []struct {
+		name   string
+		logger *slog.Logger
+		want   *slog.Logger
+	}{
+		{
+			name:   "[SUCCESS] Should return the logger of the context",
+			logger: logger,
+			want:   logger,
+		},
+
+		{
+			name:   "[SUCCESS] Should return a discard logger if the context has none",
+			logger: nil,
+			want:   discardLogger,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				ctx := context.Background()
+				if test.logger != nil {
+					ctx = withLogger(ctx, test.logger)
+				}
+				got := loggerFromContext(ctx)
+				assert.Same(t, test.want, got)
+			})
+		})
+	}
+}
+
+func Test_contextAttrs(t *testing.T) {
+	t.Parallel()
+
+	saga, step, other := NewIdentifier("saga"), NewIdentifier("step"), NewIdentifier("other")
+	notification, _ := NewNotification(other, Completed)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []any
+	}{
+		{
+			name: "[SUCCESS] Should return no attributes for an empty context",
+			ctx:  context.Background(),
+			want: []any{},
+		},
+
+		{
+			name: "[SUCCESS] Should return the saga, the step, the event and the attempt",
+			ctx: withAttemptNumber(withStepIdentifier(withNotification(withSagaIdentifier(
+				context.Background(), saga), notification), step), 2),
+			want: []any{
+				slog.String(LogKeySagaID, saga.String()),
+				slog.String(LogKeyStepID, step.String()),
+				slog.String(LogKeyEvent, "Completed"),
+				slog.Int(LogKeyAttempt, 2),
+			},
+		},
+
+		{
+			name: "[SUCCESS] Should use the identifier of the notification when there is no step",
+			ctx:  withNotification(context.Background(), notification),
+			want: []any{
+				slog.String(LogKeyStepID, other.String()),
+				slog.String(LogKeyEvent, "Completed"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, contextAttrs(test.ctx))
+		})
+	}
+}
+
+func Test_action_run_WithLogger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should log a recovered panic with the context attributes", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			logger, buffer := newBufferLogger()
+			step := NewIdentifier("step")
+			ctx := withStepIdentifier(withLogger(context.Background(), logger), step)
+
+			err := NewAction(func(ctx context.Context) error { panic("boom") }).run(ctx)
+
+			assert.ErrorIs(t, err, ErrActionPanicked)
+			record := buffer.find("recovering from panic")
+			if assert.NotNil(t, record) {
+				assert.Equal(t, "ERROR", record["level"])
+				assert.Equal(t, "boom", record["panic"])
+				assert.Equal(t, step.String(), record[LogKeyStepID])
+			}
+		})
+	})
+}
+
+func Test_runParallel_WithLogger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should log the error of an action with the notification attributes", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			logger, buffer := newBufferLogger()
+			saga, step := NewIdentifier("saga"), NewIdentifier("step")
+			notification, _ := NewNotification(step, Failed)
+			ctx := withNotification(withSagaIdentifier(withLogger(context.Background(), logger), saga), notification)
+
+			runParallel(ctx, []Action{NewAction(func(ctx context.Context) error { return assert.AnError })}, notification)
+
+			assert.Eventually(t, func() bool { return buffer.find("action failed") != nil }, time.Second, time.Millisecond)
+			record := buffer.find("action failed")
+			assert.Equal(t, assert.AnError.Error(), record["error"])
+			assert.Equal(t, saga.String(), record[LogKeySagaID])
+			assert.Equal(t, step.String(), record[LogKeyStepID])
+			assert.Equal(t, "Failed", record[LogKeyEvent])
+		})
+	})
+}
+
+func Test_step_Run_WithLogger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should log the retries of the step with its own logger", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			sagaLogger, sagaBuffer := newBufferLogger()
+			stepLogger, stepBuffer := newBufferLogger()
+			calls := 0
+			s := NewStep("test", func(ctx context.Context) error {
+				calls++
+				if calls == 1 {
+					return assert.AnError
+				}
+				return nil
+			}, WithStepRetrier(NewRetrier(BackoffConstant(1, 1*time.Millisecond))), WithStepLogger(stepLogger))
+
+			err := s.Run(withLogger(context.Background(), sagaLogger))
+
+			assert.NoError(t, err)
+			assert.Empty(t, sagaBuffer.records())
+			record := stepBuffer.find("retrying step")
+			if assert.NotNil(t, record) {
+				assert.Equal(t, "WARN", record["level"])
+				assert.Equal(t, float64(1), record[LogKeyAttempt])
+				assert.Equal(t, s.GetIdentifier().String(), record[LogKeyStepID])
+			}
+			assert.NotNil(t, stepBuffer.find("step finished"))
+		})
+	})
+}
+
+func Test_saga_Run_WithLogger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should log the saga with its identifier", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			logger, buffer := newBufferLogger()
+			starter := NewStep("starter", makeActionNoError(context.Background()))
+			c := NewSaga(WithSagaLogger(logger))
+			c.AddSteps(starter)
+			c.Run(context.Background(), func() bool { return starter.GetState() == Completed })
+
+			for _, msg := range []string{"saga started", "step finished", "saga completed"} {
+				record := buffer.find(msg)
+				if assert.NotNil(t, record, msg) {
+					assert.Equal(t, c.GetIdentifier().String(), record[LogKeySagaID])
+				}
+			}
+		})
+	})
+}
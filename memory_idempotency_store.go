@@ -0,0 +1,52 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryIdempotencyStore is an in-memory implementation of the IdempotencyStore interface.
+type memoryIdempotencyStore struct {
+	records map[string]IdempotencyRecord
+	mutex   sync.RWMutex
+}
+
+// NewMemoryIdempotencyStore returns a new IdempotencyStore that keeps the records in memory. It is meant for
+// tests and for sagas that are not resumed by other processes. Example:
+//
+//	saga := sagas.NewSaga(sagas.WithSagaIdempotencyStore(sagas.NewMemoryIdempotencyStore()))
+//
+// The above example will create a saga whose succeeded steps are not run twice.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{
+		records: make(map[string]IdempotencyRecord),
+	}
+}
+
+// Load returns a copy of the record of the key.
+func (s *memoryIdempotencyStore) Load(ctx context.Context, key string) (IdempotencyRecord, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	record, ok := s.records[key]
+	record.Output = append([]byte(nil), record.Output...)
+	return record, ok, nil
+}
+
+// Save stores a copy of the record of the key.
+func (s *memoryIdempotencyStore) Save(ctx context.Context, key string, record IdempotencyRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record.Output = append([]byte(nil), record.Output...)
+	s.records[key] = record
+	return nil
+}
@@ -0,0 +1,64 @@
+package sagas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_memoryIdempotencyStore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		save   bool
+		wantOK bool
+	}{
+		{
+			name:   "[SUCCESS] Should load a saved record",
+			save:   true,
+			wantOK: true,
+		},
+
+		{
+			name:   "[SUCCESS] Should not load an unknown key",
+			save:   false,
+			wantOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				store := NewMemoryIdempotencyStore()
+				output := []byte("receipt")
+				if test.save {
+					assert.NoError(t, store.Save(context.Background(), "key", IdempotencyRecord{Status: Successed, Output: output}))
+				}
+				output[0] = 'X'
+
+				got, ok, err := store.Load(context.Background(), "key")
+
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantOK, ok)
+				if test.wantOK {
+					assert.Equal(t, Successed, got.Status)
+					assert.Equal(t, []byte("receipt"), got.Output)
+				}
+			})
+		})
+	}
+
+	t.Run("[FAILURE] Should return the error of a canceled context", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		store := NewMemoryIdempotencyStore()
+		_, _, err := store.Load(ctx, "key")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, store.Save(ctx, "key", IdempotencyRecord{}), context.Canceled)
+	})
+}
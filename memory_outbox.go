@@ -0,0 +1,146 @@
+package sagas
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// memoryOutbox is an in-memory implementation of the Outbox interface. It is meant for tests and as a reference
+// for implementations backed by a database, where the records are rows of a table with an autoincrement ID that
+// are deleted, or flagged and purged later, once published.
+type memoryOutbox struct {
+	// records are the committed records that were not published yet, in the order they were committed.
+	records []OutboxRecord
+	// sequence is the last ID given to a record.
+	sequence int
+	// mutex is used to protect the records.
+	mutex sync.Mutex
+}
+
+// memoryOutboxTx is the unit of work of the memoryOutbox. It buffers the messages until it is committed.
+type memoryOutboxTx struct {
+	outbox *memoryOutbox
+	// clock gives the creation time of the committed records.
+	clock    Clock
+	messages []Message
+	done     bool
+	mutex    sync.Mutex
+}
+
+// NewMemoryOutbox returns a new Outbox that keeps its records in memory until they are published. Its units of
+// work only hold the messages, so the changes of the actions are not part of them. The records are timed with the
+// clock carried by the context given to Begin, which is the clock of the saga within an action. Example:
+//
+//	outbox := sagas.NewMemoryOutbox()
+//
+//	relay := sagas.NewOutboxRelay(outbox, publisher)
+//
+// The above example will create an in-memory outbox whose records are published by the relay.
+func NewMemoryOutbox() Outbox {
+	return &memoryOutbox{}
+}
+
+// Begin starts a unit of work that buffers the messages until it is committed.
+func (o *memoryOutbox) Begin(ctx context.Context) (context.Context, OutboxTx, error) {
+	if err := ctx.Err(); err != nil {
+		return ctx, nil, err
+	}
+	return ctx, &memoryOutboxTx{outbox: o, clock: clockFromContext(ctx)}, nil
+}
+
+// Pending returns up to limit records that were not published yet, oldest first. A limit that is not positive
+// returns every pending record.
+func (o *memoryOutbox) Pending(ctx context.Context, limit int) ([]OutboxRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	records := []OutboxRecord{}
+	for _, record := range o.records {
+		if limit > 0 && len(records) == limit {
+			break
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// MarkPublished removes the records of the given IDs, so the outbox only keeps the pending records. Unknown IDs
+// are ignored.
+func (o *memoryOutbox) MarkPublished(ctx context.Context, ids ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	published := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		published[id] = true
+	}
+
+	pending := o.records[:0]
+	for _, record := range o.records {
+		if !published[record.ID] {
+			pending = append(pending, record)
+		}
+	}
+	clear(o.records[len(pending):])
+	o.records = pending
+	return nil
+}
+
+// Enqueue adds the messages to the unit of work.
+func (tx *memoryOutboxTx) Enqueue(_ context.Context, messages ...Message) error {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	if tx.done {
+		return ErrOutboxTxDone
+	}
+	tx.messages = append(tx.messages, messages...)
+	return nil
+}
+
+// Commit stores the enqueued messages in the outbox as pending records.
+func (tx *memoryOutboxTx) Commit() error {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	if tx.done {
+		return ErrOutboxTxDone
+	}
+	tx.done = true
+
+	tx.outbox.mutex.Lock()
+	defer tx.outbox.mutex.Unlock()
+
+	now := tx.clock.Now()
+	for _, message := range tx.messages {
+		tx.outbox.sequence++
+		tx.outbox.records = append(tx.outbox.records, OutboxRecord{
+			ID:        strconv.Itoa(tx.outbox.sequence),
+			Message:   message,
+			CreatedAt: now,
+		})
+	}
+	return nil
+}
+
+// Rollback discards the enqueued messages.
+func (tx *memoryOutboxTx) Rollback() error {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	if tx.done {
+		return ErrOutboxTxDone
+	}
+	tx.done = true
+	tx.messages = nil
+	return nil
+}
@@ -0,0 +1,148 @@
+package sagas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func commitMessages(t *testing.T, outbox Outbox, messages ...Message) {
+	ctx, tx, err := outbox.Begin(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Enqueue(ctx, messages...))
+	assert.NoError(t, tx.Commit())
+}
+
+func Test_memoryOutbox_Pending(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		limit     int
+		published []string
+		want      []string
+		wantKept  int
+	}{
+		{
+			name:     "[SUCCESS] Should return every pending record oldest first",
+			limit:    0,
+			want:     []string{"a", "b", "c"},
+			wantKept: 3,
+		},
+
+		{
+			name:     "[SUCCESS] Should return up to limit records",
+			limit:    2,
+			want:     []string{"a", "b"},
+			wantKept: 3,
+		},
+
+		{
+			name:      "[SUCCESS] Should not return the published records",
+			limit:     2,
+			published: []string{"1"},
+			want:      []string{"b", "c"},
+			wantKept:  2,
+		},
+
+		{
+			name:      "[SUCCESS] Should remove every published record",
+			limit:     0,
+			published: []string{"3", "1", "2", "42"},
+			want:      []string{},
+			wantKept:  0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				outbox := NewMemoryOutbox()
+				commitMessages(t, outbox, Message{Key: "a"}, Message{Key: "b"})
+				commitMessages(t, outbox, Message{Key: "c"})
+				assert.NoError(t, outbox.MarkPublished(context.Background(), test.published...))
+
+				records, err := outbox.Pending(context.Background(), test.limit)
+
+				assert.NoError(t, err)
+				keys := []string{}
+				for _, record := range records {
+					keys = append(keys, record.Message.Key)
+					assert.False(t, record.CreatedAt.IsZero())
+				}
+				assert.Equal(t, test.want, keys)
+				assert.Len(t, outbox.(*memoryOutbox).records, test.wantKept)
+			})
+		})
+	}
+}
+
+func Test_memoryOutboxTx(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		finish      func(tx OutboxTx) error
+		wantPending int
+	}{
+		{
+			name:        "[SUCCESS] Should store the messages on commit",
+			finish:      func(tx OutboxTx) error { return tx.Commit() },
+			wantPending: 1,
+		},
+
+		{
+			name:        "[SUCCESS] Should discard the messages on rollback",
+			finish:      func(tx OutboxTx) error { return tx.Rollback() },
+			wantPending: 0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				outbox := NewMemoryOutbox()
+				ctx, tx, _ := outbox.Begin(context.Background())
+				assert.NoError(t, tx.Enqueue(ctx, Message{Topic: "orders"}))
+
+				pending, _ := outbox.Pending(ctx, 0)
+				assert.Empty(t, pending)
+
+				assert.NoError(t, test.finish(tx))
+				assert.ErrorIs(t, tx.Commit(), ErrOutboxTxDone)
+				assert.ErrorIs(t, tx.Rollback(), ErrOutboxTxDone)
+				assert.ErrorIs(t, tx.Enqueue(ctx, Message{}), ErrOutboxTxDone)
+
+				pending, _ = outbox.Pending(ctx, 0)
+				assert.Len(t, pending, test.wantPending)
+			})
+		})
+	}
+}
+
+func Test_memoryOutboxTx_Commit_WithClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should time the records with the clock carried by the context", func(t *testing.T) {
+		t.Parallel()
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		outbox := NewMemoryOutbox()
+		ctx, tx, err := outbox.Begin(withClock(context.Background(), NewFakeClock(now)))
+		assert.NoError(t, err)
+		assert.NoError(t, tx.Enqueue(ctx, Message{Key: "a"}))
+		assert.NoError(t, tx.Commit())
+
+		records, err := outbox.Pending(ctx, 0)
+
+		assert.NoError(t, err)
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, now, records[0].CreatedAt)
+		}
+	})
+}
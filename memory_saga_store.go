@@ -0,0 +1,112 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+)
+
+// memorySagaStore is an in-memory implementation of the SagaStore interface.
+type memorySagaStore struct {
+	parked  map[string][]ParkedStep
+	signals map[string]map[string][][]byte
+	mutex   sync.Mutex
+}
+
+// NewMemorySagaStore returns a new SagaStore that keeps the parked state in memory. It is the store used by the
+// sagas when none is provided, so their parked steps can not be resumed by another process. Example:
+//
+//	store := sagas.NewMemorySagaStore()
+//
+//	saga := sagas.NewSaga(sagas.WithSagaStore(store))
+//
+// The above example will create a saga whose parked state is kept in the store.
+func NewMemorySagaStore() SagaStore {
+	return &memorySagaStore{
+		parked:  make(map[string][]ParkedStep),
+		signals: make(map[string]map[string][][]byte),
+	}
+}
+
+// Park records the parked step, replacing any previous record of the step.
+func (s *memorySagaStore) Park(ctx context.Context, saga Identifier, parked ParkedStep) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.parked[saga.String()] = append(s.remove(saga, parked.Step), parked)
+	return nil
+}
+
+// Unpark removes the record of the parked step.
+func (s *memorySagaStore) Unpark(ctx context.Context, saga Identifier, step Identifier) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.parked[saga.String()] = s.remove(saga, step)
+	return nil
+}
+
+// Parked returns a copy of the parked steps of the saga.
+func (s *memorySagaStore) Parked(ctx context.Context, saga Identifier) ([]ParkedStep, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]ParkedStep{}, s.parked[saga.String()]...), nil
+}
+
+// SaveSignal appends a copy of the signal to the signals of the name.
+func (s *memorySagaStore) SaveSignal(ctx context.Context, saga Identifier, name string, payload []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	signals, ok := s.signals[saga.String()]
+	if !ok {
+		signals = make(map[string][][]byte)
+		s.signals[saga.String()] = signals
+	}
+	signals[name] = append(signals[name], append([]byte(nil), payload...))
+	return nil
+}
+
+// TakeSignal removes and returns the oldest signal of the name.
+func (s *memorySagaStore) TakeSignal(ctx context.Context, saga Identifier, name string) ([]byte, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pending := s.signals[saga.String()][name]
+	if len(pending) == 0 {
+		return nil, false, nil
+	}
+	s.signals[saga.String()][name] = pending[1:]
+	return pending[0], true, nil
+}
+
+// remove returns the parked steps of the saga without the given step. The mutex must be held.
+func (s *memorySagaStore) remove(saga Identifier, step Identifier) []ParkedStep {
+	parked := []ParkedStep{}
+	for _, p := range s.parked[saga.String()] {
+		if p.Step.String() != step.String() {
+			parked = append(parked, p)
+		}
+	}
+	return parked
+}
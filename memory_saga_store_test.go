@@ -0,0 +1,94 @@
+package sagas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_memorySagaStore_Park(t *testing.T) {
+	t.Parallel()
+
+	saga, step := NewStableIdentifier("saga"), NewStableIdentifier("step")
+	deadline := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		park   []ParkedStep
+		unpark []Identifier
+		want   []ParkedStep
+	}{
+		{
+			name: "[SUCCESS] Should keep the parked steps",
+			park: []ParkedStep{{Step: step, Signal: "approval"}},
+			want: []ParkedStep{{Step: step, Signal: "approval"}},
+		},
+
+		{
+			name: "[SUCCESS] Should replace the record of a step parked again",
+			park: []ParkedStep{{Step: step, Signal: "approval"}, {Step: step, Deadline: deadline}},
+			want: []ParkedStep{{Step: step, Deadline: deadline}},
+		},
+
+		{
+			name:   "[SUCCESS] Should remove an unparked step",
+			park:   []ParkedStep{{Step: step, Signal: "approval"}},
+			unpark: []Identifier{step},
+			want:   []ParkedStep{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				store := NewMemorySagaStore()
+				for _, parked := range test.park {
+					assert.NoError(t, store.Park(context.Background(), saga, parked))
+				}
+				for _, step := range test.unpark {
+					assert.NoError(t, store.Unpark(context.Background(), saga, step))
+				}
+
+				got, err := store.Parked(context.Background(), saga)
+
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+				other, _ := store.Parked(context.Background(), NewStableIdentifier("other"))
+				assert.Empty(t, other)
+			})
+		})
+	}
+}
+
+func Test_memorySagaStore_Signals(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should take the signals of a name oldest first", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			store := NewMemorySagaStore()
+			saga := NewStableIdentifier("saga")
+			ctx := context.Background()
+			assert.NoError(t, store.SaveSignal(ctx, saga, "approval", []byte("first")))
+			assert.NoError(t, store.SaveSignal(ctx, saga, "approval", []byte("second")))
+			assert.NoError(t, store.SaveSignal(ctx, saga, "other", []byte("other")))
+
+			for _, want := range []string{"first", "second"} {
+				payload, ok, err := store.TakeSignal(ctx, saga, "approval")
+				assert.NoError(t, err)
+				assert.True(t, ok)
+				assert.Equal(t, want, string(payload))
+			}
+
+			_, ok, err := store.TakeSignal(ctx, saga, "approval")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+			_, ok, _ = store.TakeSignal(ctx, NewStableIdentifier("unknown"), "approval")
+			assert.False(t, ok)
+		})
+	})
+}
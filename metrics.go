@@ -0,0 +1,65 @@
+package sagas
+
+import (
+	"context"
+	"time"
+)
+
+// MetricsRecorder is the interface that wraps the hooks called to collect metrics of the execution of sagas,
+// steps and attempts. It is installed in a saga with the WithSagaMetrics option and must be safe for
+// concurrent use, since it is shared by every step of the saga.
+type MetricsRecorder interface {
+	// SagaStarted is called when a saga starts running.
+	SagaStarted(saga Identifier)
+	// SagaCompleted is called when a saga ends running, with the time it took.
+	SagaCompleted(saga Identifier, duration time.Duration)
+	// SagaCompensated is called when a compensation step of a saga starts running.
+	SagaCompensated(saga Identifier, step Identifier)
+	// StepFinished is called when a step ends running, with its final status and the time it took.
+	StepFinished(step Identifier, status Status, duration time.Duration)
+	// AttemptFinished is called after each attempt of a step, with the attempt and whether it will be retried.
+	// The status of the attempt is the classification of its error.
+	AttemptFinished(step Identifier, attempt Attempt, retrying bool)
+}
+
+// QueueMetricsRecorder is the interface that wraps the hook called to collect the time the actions of a saga
+// wait for a worker of its WorkerPool. A MetricsRecorder that also implements it receives the queueing delays.
+type QueueMetricsRecorder interface {
+	// ActionQueued is called when a worker of the pool picks up an action of the saga, with the time it waited.
+	ActionQueued(saga Identifier, delay time.Duration)
+}
+
+// noopMetrics is the MetricsRecorder used when no MetricsRecorder is provided. It does not record anything.
+type noopMetrics struct{}
+
+// SagaStarted does nothing.
+func (noopMetrics) SagaStarted(Identifier) {}
+
+// SagaCompleted does nothing.
+func (noopMetrics) SagaCompleted(Identifier, time.Duration) {}
+
+// SagaCompensated does nothing.
+func (noopMetrics) SagaCompensated(Identifier, Identifier) {}
+
+// StepFinished does nothing.
+func (noopMetrics) StepFinished(Identifier, Status, time.Duration) {}
+
+// AttemptFinished does nothing.
+func (noopMetrics) AttemptFinished(Identifier, Attempt, bool) {}
+
+// metricsKey is the context key of the MetricsRecorder.
+type metricsKey struct{}
+
+// withMetrics returns a copy of the context that carries the given MetricsRecorder.
+func withMetrics(ctx context.Context, metrics MetricsRecorder) context.Context {
+	return context.WithValue(ctx, metricsKey{}, metrics)
+}
+
+// metricsFromContext returns the MetricsRecorder carried by the context. If there is none, it returns a
+// MetricsRecorder that does not record anything.
+func metricsFromContext(ctx context.Context) MetricsRecorder {
+	if metrics, ok := ctx.Value(metricsKey{}).(MetricsRecorder); ok {
+		return metrics
+	}
+	return noopMetrics{}
+}
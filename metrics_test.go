@@ -0,0 +1,171 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingMetrics struct {
+	calls []string
+	mutex sync.Mutex
+}
+
+func (m *recordingMetrics) record(call string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.calls = append(m.calls, call)
+}
+
+func (m *recordingMetrics) SagaStarted(Identifier) { m.record("saga started") }
+
+func (m *recordingMetrics) SagaCompleted(Identifier, time.Duration) { m.record("saga completed") }
+
+func (m *recordingMetrics) SagaCompensated(Identifier, Identifier) { m.record("saga compensated") }
+
+func (m *recordingMetrics) StepFinished(_ Identifier, status Status, _ time.Duration) {
+	m.record("step " + status.String())
+}
+
+func (m *recordingMetrics) AttemptFinished(_ Identifier, attempt Attempt, retrying bool) {
+	if retrying {
+		m.record("attempt retrying")
+		return
+	}
+	m.record("attempt " + attempt.Status.String())
+}
+
+func (m *recordingMetrics) recorded() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+func Test_metricsFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		metrics MetricsRecorder
+		want    MetricsRecorder
+	}{
+		{
+			name:    "[SUCCESS] Should return the metrics recorder of the context",
+			metrics: &recordingMetrics{},
+			want:    &recordingMetrics{},
+		},
+
+		{
+			name:    "[SUCCESS] Should return a noop metrics recorder if the context has none",
+			metrics: nil,
+			want:    noopMetrics{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				ctx := context.Background()
+				if test.metrics != nil {
+					ctx = withMetrics(ctx, test.metrics)
+				}
+				got := metricsFromContext(ctx)
+				assert.Equal(t, test.want, got)
+			})
+		})
+	}
+}
+
+func Test_step_Run_WithMetrics(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		failures int
+		options  []StepOption
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "[SUCCESS] Should record the attempt and the step",
+			args: args{},
+			want: []string{"attempt Successed", "step Successed"},
+		},
+
+		{
+			name: "[SUCCESS] Should record every attempt of the retrier",
+			args: args{
+				failures: 1,
+				options:  []StepOption{WithStepRetrier(NewRetrier(BackoffConstant(1, 1*time.Millisecond)))},
+			},
+			want: []string{"attempt retrying", "attempt Successed", "step Successed"},
+		},
+
+		{
+			name: "[SUCCESS] Should record the failure of the step",
+			args: args{
+				failures: 1,
+			},
+			want: []string{"attempt Failed", "step Failed"},
+		},
+
+		{
+			name: "[SUCCESS] Should record a compensation step",
+			args: args{
+				options: []StepOption{WithStepCompensation()},
+			},
+			want: []string{"saga compensated", "attempt Successed", "step Successed"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				metrics := &recordingMetrics{}
+				calls := 0
+				s := NewStep("test", func(ctx context.Context) error {
+					calls++
+					if calls <= test.args.failures {
+						return assert.AnError
+					}
+					return nil
+				}, test.args.options...)
+
+				_ = s.Run(withMetrics(context.Background(), metrics))
+
+				assert.Equal(t, test.want, metrics.recorded())
+			})
+		})
+	}
+}
+
+func Test_saga_Run_WithMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should record the saga and its steps", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			metrics := &recordingMetrics{}
+			starter := NewStep("starter", makeActionNoError(context.Background()))
+			c := NewSaga(WithSagaMetrics(metrics))
+			c.AddSteps(starter)
+			c.Run(context.Background(), func() bool { return starter.GetState() == Completed })
+
+			assert.Eventually(t, func() bool {
+				return len(metrics.recorded()) == 4
+			}, time.Second, time.Millisecond)
+			assert.Equal(t, []string{"saga started", "attempt Successed", "step Successed", "saga completed"},
+				metrics.recorded())
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package sagas
+
+import "context"
+
+// ActionMiddleware is a function that wraps an ActionFn with a cross-cutting concern, such as authentication,
+// logging or panic handling. The middlewares wrap every attempt of the steps, including the retries and the
+// compensations.
+type ActionMiddleware func(ActionFn) ActionFn
+
+// chainMiddlewares returns the actionFn wrapped by the middlewares. The first middleware is the outermost, so it
+// is the first to run before the actionFn and the last to run after it.
+func chainMiddlewares(actionFn ActionFn, middlewares ...ActionMiddleware) ActionFn {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		actionFn = middlewares[i](actionFn)
+	}
+	return actionFn
+}
+
+// middlewaresKey is the context key of the middlewares of the saga.
+type middlewaresKey struct{}
+
+// withMiddlewares returns a copy of the context that carries the given middlewares.
+func withMiddlewares(ctx context.Context, middlewares []ActionMiddleware) context.Context {
+	return context.WithValue(ctx, middlewaresKey{}, middlewares)
+}
+
+// middlewaresFromContext returns the middlewares carried by the context, if any.
+func middlewaresFromContext(ctx context.Context) []ActionMiddleware {
+	middlewares, _ := ctx.Value(middlewaresKey{}).([]ActionMiddleware)
+	return middlewares
+}
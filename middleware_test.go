@@ -0,0 +1,123 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tracingMiddleware returns a middleware that appends its name to the calls before and after the action.
+func tracingMiddleware(name string, calls *[]string, mutex *sync.Mutex) ActionMiddleware {
+	return func(next ActionFn) ActionFn {
+		return func(ctx context.Context) error {
+			mutex.Lock()
+			*calls = append(*calls, "before "+name)
+			mutex.Unlock()
+			err := next(ctx)
+			mutex.Lock()
+			*calls = append(*calls, "after "+name)
+			mutex.Unlock()
+			return err
+		}
+	}
+}
+
+func Test_chainMiddlewares(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	var mutex sync.Mutex
+
+	actionFn := chainMiddlewares(func(context.Context) error {
+		calls = append(calls, "action")
+		return nil
+	}, tracingMiddleware("first", &calls, &mutex), tracingMiddleware("second", &calls, &mutex))
+
+	assert.NoError(t, actionFn(context.Background()))
+	assert.Equal(t, []string{"before first", "before second", "action", "after second", "after first"}, calls)
+}
+
+func Test_step_Run_WithMiddleware(t *testing.T) {
+	t.Parallel()
+
+	recoverMiddleware := func(next ActionFn) ActionFn {
+		return func(ctx context.Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("panic: %v", r)
+				}
+			}()
+			return next(ctx)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		action     ActionFn
+		retrier    Retrier
+		wantCalls  []string
+		wantStatus Status
+	}{
+		{
+			name:       "[SUCCESS] Should wrap the action with the middlewares of the saga and then of the step",
+			action:     func(context.Context) error { return nil },
+			wantCalls:  []string{"before saga", "before step", "after step", "after saga"},
+			wantStatus: Successed,
+		},
+
+		{
+			name: "[SUCCESS] Should wrap every attempt of a retried step",
+			action: func() ActionFn {
+				attempts := 0
+				return func(context.Context) error {
+					attempts++
+					if attempts == 1 {
+						return errors.New("error")
+					}
+					return nil
+				}
+			}(),
+			retrier: NewRetrier(BackoffConstant(1, time.Millisecond)),
+			wantCalls: []string{
+				"before saga", "before step", "after step", "after saga",
+				"before saga", "before step", "after step", "after saga",
+			},
+			wantStatus: Successed,
+		},
+
+		{
+			name:       "[FAILURE] Should let the middlewares handle the panics of the action",
+			action:     func(context.Context) error { panic("boom") },
+			wantCalls:  []string{"before saga", "before step", "after saga"},
+			wantStatus: Failed,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var calls []string
+			var mutex sync.Mutex
+
+			options := []StepOption{WithStepMiddleware(recoverMiddleware, tracingMiddleware("step", &calls, &mutex))}
+			if test.retrier != nil {
+				options = append(options, WithStepRetrier(test.retrier))
+			}
+			s := NewStep("step", test.action, options...)
+			c := NewSaga(WithSagaMiddleware(tracingMiddleware("saga", &calls, &mutex)))
+			c.AddSteps(s)
+
+			c.Run(context.Background(), func() bool { return s.GetState() == Completed })
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			assert.Equal(t, test.wantCalls, calls)
+			assert.Equal(t, test.wantStatus, s.GetStatus())
+		})
+	}
+}
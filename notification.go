@@ -1,6 +1,10 @@
 package sagas
 
-import "errors"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
 
 // Notification is a struct that represents a Notification.
 type Notification struct {
@@ -10,6 +14,15 @@ type Notification struct {
 	// Event is an interface that represents a state or status Event emitted by
 	// the step.
 	Event Event
+	// Attempt is the attempt of the step that originated the notification. It is
+	// only set in Retrying notifications.
+	Attempt *Attempt
+	// Saga is the identifier of the saga instance that ran the step. It is nil if
+	// the step ran on its own.
+	Saga Identifier
+	// Parent is the identifier of the parent of the saga instance, if the saga ran
+	// as a step of another saga.
+	Parent Identifier
 }
 
 // NewNotification is a function that creates a new notification struct.
@@ -42,6 +55,72 @@ func NewNotification(id Identifier, event Event) (Notification, error) {
 	}, nil
 }
 
+// jsonNotification is the JSON representation of a Notification. The event is kept as its name.
+type jsonNotification struct {
+	Identifier identifier `json:"identifier"`
+	Event      string     `json:"event"`
+	Attempt    *Attempt   `json:"attempt,omitempty"`
+	Saga       string     `json:"saga,omitempty"`
+	Parent     string     `json:"parent,omitempty"`
+}
+
+// MarshalJSON returns the JSON representation of the notification. It returns an error if the identifier or
+// the event are not valid.
+func (n Notification) MarshalJSON() ([]byte, error) {
+	if n.Identifier == nil {
+		return nil, errors.New("invalid identifier")
+	}
+
+	if err := validateEvent(n.Event); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonNotification{
+		Identifier: identifier(n.Identifier.String()),
+		Event:      n.Event.String(),
+		Attempt:    n.Attempt,
+		Saga:       identifierString(n.Saga),
+		Parent:     identifierString(n.Parent),
+	})
+}
+
+// UnmarshalJSON parses the JSON representation of a notification. The identifier is parsed with
+// ParseIdentifier and the event with ParseStatus or ParseState.
+func (n *Notification) UnmarshalJSON(data []byte) error {
+	wire := jsonNotification{}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	event, err := parseEvent(wire.Event)
+	if err != nil {
+		return err
+	}
+
+	notification, err := NewNotification(wire.Identifier, event)
+	if err != nil {
+		return err
+	}
+
+	notification.Attempt = wire.Attempt
+	if wire.Saga != "" {
+		notification.Saga = identifier(wire.Saga)
+	}
+	if wire.Parent != "" {
+		notification.Parent = identifier(wire.Parent)
+	}
+	*n = notification
+	return nil
+}
+
+// identifierString returns the string representation of the identifier, or an empty string if it is nil.
+func identifierString(id Identifier) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
 // validateEvent is a function that validates an event. It receives an event as
 // parameter and returns an error. If the event is not a State or Status, it
 // returns an error.
@@ -61,3 +140,17 @@ func isStatus(event Event) bool {
 	_, ok := event.(Status)
 	return ok
 }
+
+// notificationKey is the context key of the notification that triggered the actions of the execution plan.
+type notificationKey struct{}
+
+// withNotification returns a copy of the context that carries the given notification.
+func withNotification(ctx context.Context, notification Notification) context.Context {
+	return context.WithValue(ctx, notificationKey{}, notification)
+}
+
+// notificationFromContext returns the notification carried by the context, if any.
+func notificationFromContext(ctx context.Context) (Notification, bool) {
+	notification, ok := ctx.Value(notificationKey{}).(Notification)
+	return notification, ok
+}
@@ -1,7 +1,9 @@
 package sagas
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -99,3 +101,62 @@ type mockEvent struct{}
 func (m mockEvent) String() string {
 	return "mock"
 }
+
+func Test_Notification_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		notification Notification
+		want         string
+	}{
+		{
+			name:         "[SUCCESS] Should round trip a notification",
+			notification: Notification{Identifier: NewStableIdentifier("step"), Event: Successed},
+			want:         `{"identifier":"step","event":"Successed"}`,
+		},
+
+		{
+			name: "[SUCCESS] Should round trip a notification with its attempt",
+			notification: Notification{
+				Identifier: NewStableIdentifier("step"),
+				Event:      Retrying,
+				Attempt:    &Attempt{Number: 1, Status: Retry, NextDelay: time.Second},
+			},
+			want: `{"identifier":"step","event":"Retrying","attempt":{"number":1,` +
+				`"started_at":"0001-01-01T00:00:00Z","ended_at":"0001-01-01T00:00:00Z","status":"Retry",` +
+				`"next_delay":1000000000}}`,
+		},
+
+		{
+			name: "[SUCCESS] Should round trip a notification linked to its saga and parent",
+			notification: Notification{
+				Identifier: NewStableIdentifier("step"),
+				Event:      Completed,
+				Saga:       NewStableIdentifier("checkout/payment"),
+				Parent:     NewStableIdentifier("checkout"),
+			},
+			want: `{"identifier":"step","event":"Completed","saga":"checkout/payment","parent":"checkout"}`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			data, err := json.Marshal(test.notification)
+			assert.NoError(t, err)
+			assert.JSONEq(t, test.want, string(data))
+
+			got := Notification{}
+			assert.NoError(t, json.Unmarshal(data, &got))
+			assert.Equal(t, test.notification, got)
+		})
+	}
+
+	t.Run("[FAILURE] Should not unmarshal a notification without identifier", func(t *testing.T) {
+		t.Parallel()
+		got := Notification{}
+		assert.Error(t, json.Unmarshal([]byte(`{"event":"Successed"}`), &got))
+	})
+}
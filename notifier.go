@@ -16,10 +16,13 @@ type Notifier interface {
 
 // notifier is the concrete implementation of the Notifier interface.
 type notifier struct {
-	observers []Observer
+	observers      []Observer
+	maxConcurrency int
 }
 
-// NewNotifier returns a new notifier. It returns a Notifier.
+// NewNotifier returns a new notifier. It returns a Notifier. The observers
+// are notified in parallel, at most as many at once as the maximum
+// concurrency of the options, if any.
 // Example:
 //
 //	identifier := sagas.Identifier("identifier")
@@ -38,9 +41,12 @@ type notifier struct {
 //
 // The above example will create a new notifier and notify the observers that a
 // notification occurred.
-func NewNotifier() Notifier {
+func NewNotifier(options ...NotifierOption) Notifier {
+	notifierOptions := newNotifierOptions(options...)
+
 	return &notifier{
-		observers: make([]Observer, 0),
+		observers:      make([]Observer, 0),
+		maxConcurrency: notifierOptions.MaxConcurrency,
 	}
 }
 
@@ -50,12 +56,31 @@ func (n *notifier) Add(observer Observer) {
 }
 
 // Notify send to all observers in parallel that an notification occurred.
+// It returns once every observer was notified.
 func (n *notifier) Notify(ctx context.Context, notification Notification) {
+	if n.maxConcurrency == 1 {
+		for _, obs := range n.observers {
+			obs.Execute(ctx, notification)
+		}
+		return
+	}
+
+	var slots chan struct{}
+	if n.maxConcurrency > 0 {
+		slots = make(chan struct{}, n.maxConcurrency)
+	}
+
 	wg := sync.WaitGroup{}
 	for _, obs := range n.observers {
 		wg.Add(1)
+		if slots != nil {
+			slots <- struct{}{}
+		}
 		go func(o Observer) {
 			defer wg.Done()
+			if slots != nil {
+				defer func() { <-slots }()
+			}
 			o.Execute(ctx, notification)
 		}(obs)
 	}
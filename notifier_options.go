@@ -0,0 +1,27 @@
+package sagas
+
+type notifierOptions struct {
+	MaxConcurrency int
+}
+
+type NotifierOption func(*notifierOptions)
+
+func newNotifierOptions(opts ...NotifierOption) notifierOptions {
+	options := notifierOptions{
+		MaxConcurrency: 0,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// WithNotifierMaxConcurrency limits the number of observers that are notified at once. With a maximum of 1, the
+// observers are notified in the order they were added. By default, every observer is notified at once.
+func WithNotifierMaxConcurrency(maximum int) NotifierOption {
+	return func(o *notifierOptions) {
+		o.MaxConcurrency = maximum
+	}
+}
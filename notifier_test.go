@@ -2,7 +2,10 @@ package sagas
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -106,3 +109,64 @@ func Test_notifier_Notify(t *testing.T) {
 		})
 	}
 }
+
+func Test_notifier_Notify_MaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	n, _ := NewNotification(NewIdentifier("test"), Running)
+
+	tests := []struct {
+		name      string
+		maximum   int
+		observers int
+		wantOrder bool
+	}{
+		{
+			name:      "[SUCCESS] Should notify the observers in order with a maximum of one",
+			maximum:   1,
+			observers: 5,
+			wantOrder: true,
+		},
+		{
+			name:      "[SUCCESS] Should notify at most the maximum of observers at once",
+			maximum:   2,
+			observers: 8,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var running, peak int32
+			var mutex sync.Mutex
+			var order []int
+
+			notifier := NewNotifier(WithNotifierMaxConcurrency(test.maximum))
+			for i := 0; i < test.observers; i++ {
+				i := i
+				notifier.Add(NewObserverFunc(func(context.Context, Notification) {
+					current := atomic.AddInt32(&running, 1)
+					for {
+						old := atomic.LoadInt32(&peak)
+						if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+							break
+						}
+					}
+					time.Sleep(2 * time.Millisecond)
+					mutex.Lock()
+					order = append(order, i)
+					mutex.Unlock()
+					atomic.AddInt32(&running, -1)
+				}))
+			}
+			notifier.Notify(context.Background(), n)
+
+			assert.Len(t, order, test.observers)
+			assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(test.maximum))
+			if test.wantOrder {
+				assert.Equal(t, []int{0, 1, 2, 3, 4}, order)
+			}
+		})
+	}
+}
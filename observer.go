@@ -63,3 +63,61 @@ func (o *observer) Execute(ctx context.Context, notification Notification) {
 func (o *observer) getExecutionPlan() ExecutionPlan {
 	return o.executionPlan
 }
+
+// funcObserver is an Observer that calls a function with every notification.
+type funcObserver struct {
+	fn func(context.Context, Notification)
+}
+
+// NewObserverFunc returns a new Observer that calls the given function with every
+// notification, instead of running an execution plan. It is used to watch the
+// notifications of the steps of a saga from other packages. A panic will occur if
+// the function is nil. Example:
+//
+//	observer := sagas.NewObserverFunc(func(ctx context.Context, notification sagas.Notification) {
+//		fmt.Println(notification.Identifier, notification.Event)
+//	})
+//
+//	saga := sagas.NewSaga(sagas.WithSagaObserver(observer))
+//
+// The above example will print every notification of the steps of the saga.
+func NewObserverFunc(fn func(context.Context, Notification)) Observer {
+	if fn == nil {
+		panic("fn can not be nil")
+	}
+
+	return &funcObserver{
+		fn: fn,
+	}
+}
+
+// Execute calls the function with the given notification.
+func (o *funcObserver) Execute(ctx context.Context, notification Notification) {
+	o.fn(ctx, notification)
+}
+
+// getExecutionPlan returns nil, since the observer has no execution plan.
+func (o *funcObserver) getExecutionPlan() ExecutionPlan {
+	return nil
+}
+
+// observerChain is an Observer that executes its observers one after the other, in
+// order, so that the first ones see a notification before the last ones act on it.
+type observerChain struct {
+	observers []Observer
+}
+
+// Execute executes the given notification through every observer, in order.
+func (o *observerChain) Execute(ctx context.Context, notification Notification) {
+	for _, observer := range o.observers {
+		observer.Execute(ctx, notification)
+	}
+}
+
+// getExecutionPlan returns the execution plan of the last observer of the chain.
+func (o *observerChain) getExecutionPlan() ExecutionPlan {
+	if len(o.observers) == 0 {
+		return nil
+	}
+	return o.observers[len(o.observers)-1].getExecutionPlan()
+}
@@ -99,3 +99,24 @@ func Test_observer_GetNotification(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewObserverFunc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should call the function with the notification", func(t *testing.T) {
+		t.Parallel()
+		notification, _ := NewNotification(NewIdentifier("step"), Completed)
+		var got Notification
+		observer := NewObserverFunc(func(_ context.Context, n Notification) { got = n })
+
+		observer.Execute(context.Background(), notification)
+
+		assert.Equal(t, notification, got)
+		assert.Nil(t, observer.getExecutionPlan())
+	})
+
+	t.Run("[PANIC] Should panic if the function is nil", func(t *testing.T) {
+		t.Parallel()
+		assert.Panics(t, func() { NewObserverFunc(nil) })
+	})
+}
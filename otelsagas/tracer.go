@@ -0,0 +1,101 @@
+/*
+The otelsagas package adapts the sagas.Tracer hooks to OpenTelemetry. Each saga, step, attempt and compensation
+becomes an OpenTelemetry span, and the spans started by the actions are children of the span of their step.
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+
+	saga := sagas.NewSaga(sagas.WithSagaTracer(otelsagas.NewTracer(provider)))
+*/
+package otelsagas
+
+import (
+	"context"
+
+	"github.com/rilder-almeida/sagas"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the OpenTelemetry tracer used by the adapter.
+const instrumentationName = "github.com/rilder-almeida/sagas"
+
+const (
+	// SagaIDKey is the attribute key of the identifier of the saga instance.
+	SagaIDKey = attribute.Key("saga.id")
+	// StepIDKey is the attribute key of the identifier of the step.
+	StepIDKey = attribute.Key("saga.step.id")
+	// AttemptKey is the attribute key of the number of the attempt.
+	AttemptKey = attribute.Key("saga.step.attempt")
+	// CompensationKey is the attribute key that indicates whether the step is a compensation.
+	CompensationKey = attribute.Key("saga.step.compensation")
+)
+
+// tracer is the concrete implementation of the sagas.Tracer interface backed by OpenTelemetry.
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a sagas.Tracer that starts OpenTelemetry spans with a tracer of the given provider.
+// A panic will occur if the provider is nil. Example:
+//
+//	exporter := tracetest.NewInMemoryExporter()
+//
+//	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+//
+//	tracer := otelsagas.NewTracer(provider)
+//
+// The above example will create a new tracer that exports its spans to memory.
+func NewTracer(provider trace.TracerProvider) sagas.Tracer {
+	if provider == nil {
+		panic("provider can not be nil")
+	}
+
+	return &tracer{
+		tracer: provider.Tracer(instrumentationName),
+	}
+}
+
+// StartSaga starts the span of a saga execution.
+func (t *tracer) StartSaga(ctx context.Context, saga sagas.Identifier) (context.Context, sagas.Span) {
+	return t.start(ctx, "saga", SagaIDKey.String(saga.String()))
+}
+
+// StartStep starts the span of a step execution. The span is named after the name of the step, while its
+// identifier is kept in the StepIDKey attribute.
+func (t *tracer) StartStep(ctx context.Context, step sagas.Identifier) (context.Context, sagas.Span) {
+	return t.start(ctx, "step "+sagas.IdentifierName(step), StepIDKey.String(step.String()),
+		CompensationKey.Bool(false))
+}
+
+// StartAttempt starts the span of a single attempt of a step.
+func (t *tracer) StartAttempt(ctx context.Context, step sagas.Identifier, attempt int) (context.Context, sagas.Span) {
+	return t.start(ctx, "attempt "+sagas.IdentifierName(step), StepIDKey.String(step.String()),
+		AttemptKey.Int(attempt))
+}
+
+// StartCompensation starts the span of a compensation step execution.
+func (t *tracer) StartCompensation(ctx context.Context, step sagas.Identifier) (context.Context, sagas.Span) {
+	return t.start(ctx, "compensation "+sagas.IdentifierName(step), StepIDKey.String(step.String()),
+		CompensationKey.Bool(true))
+}
+
+// start starts an internal span with the given name and attributes.
+func (t *tracer) start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, sagas.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+	return ctx, &otelSpan{span: span}
+}
+
+// otelSpan is the concrete implementation of the sagas.Span interface backed by an OpenTelemetry span.
+type otelSpan struct {
+	span trace.Span
+}
+
+// End ends the span, recording the error and setting the error status if the error is not nil.
+func (s *otelSpan) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
@@ -0,0 +1,129 @@
+package otelsagas
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rilder-almeida/sagas"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_NewTracer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		provider    *sdktrace.TracerProvider
+		shouldPanic bool
+	}{
+		{
+			name:     "[SUCCESS] Should return a new Tracer",
+			provider: sdktrace.NewTracerProvider(),
+		},
+
+		{
+			name:        "[PANIC] Should panic if the provider is nil",
+			provider:    nil,
+			shouldPanic: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if test.shouldPanic {
+				assert.Panics(t, func() {
+					NewTracer(nil)
+				})
+				return
+			}
+			assert.NotPanics(t, func() {
+				got := NewTracer(test.provider)
+				assert.NotNil(t, got)
+			})
+		})
+	}
+}
+
+func Test_tracer_Saga(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		step     string
+		failures int
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantSpans []string
+	}{
+		{
+			name:      "[SUCCESS] Should export the spans of the saga, the step and the attempt",
+			args:      args{step: "payment", failures: 0},
+			wantSpans: []string{"attempt payment", "step payment", "saga"},
+		},
+
+		{
+			name:      "[SUCCESS] Should keep the colons of the name of the step in the names of the spans",
+			args:      args{step: "orders:charge", failures: 0},
+			wantSpans: []string{"attempt orders:charge", "step orders:charge", "saga"},
+		},
+
+		{
+			name:      "[SUCCESS] Should export the spans of every attempt and record their errors",
+			args:      args{step: "payment", failures: 1},
+			wantSpans: []string{"attempt payment", "attempt payment", "step payment", "saga"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				exporter := tracetest.NewInMemoryExporter()
+				provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+				calls := 0
+				step := sagas.NewStep(test.args.step, func(ctx context.Context) error {
+					calls++
+					if calls <= test.args.failures {
+						return errors.New("failed")
+					}
+					return nil
+				}, sagas.WithStepRetrier(sagas.NewRetrier(sagas.BackoffConstant(1, 1*time.Millisecond))))
+
+				saga := sagas.NewSaga(sagas.WithSagaTracer(NewTracer(provider)))
+				saga.AddSteps(step)
+				saga.Run(context.Background(), func() bool { return step.GetState() == sagas.Completed })
+
+				spans := exporter.GetSpans()
+				names := make([]string, 0, len(spans))
+				for _, span := range spans {
+					names = append(names, span.Name)
+				}
+				assert.Equal(t, test.wantSpans, names)
+				assert.Contains(t, spans[len(spans)-2].Attributes, StepIDKey.String(step.GetIdentifier().String()))
+
+				sagaSpan, stepSpan := spans[len(spans)-1], spans[len(spans)-2]
+				assert.Equal(t, sagaSpan.SpanContext.SpanID(), stepSpan.Parent.SpanID())
+				for _, attempt := range spans[:len(spans)-2] {
+					assert.Equal(t, stepSpan.SpanContext.SpanID(), attempt.Parent.SpanID())
+					assert.Equal(t, sagaSpan.SpanContext.TraceID(), attempt.SpanContext.TraceID())
+				}
+				if test.args.failures > 0 {
+					assert.Equal(t, codes.Error, spans[0].Status.Code)
+					assert.Len(t, spans[0].Events, 1)
+				}
+				assert.Equal(t, codes.Unset, stepSpan.Status.Code)
+			})
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrOutboxTxDone is returned by an OutboxTx that is used after being committed or rolled back.
+var ErrOutboxTxDone = errors.New("outbox transaction already committed or rolled back")
+
+// Message is an outgoing message written to an Outbox by an action and published by an OutboxRelay.
+type Message struct {
+	// Topic is the destination of the message.
+	Topic string
+	// Key is the key of the message, used by the publishers to partition or deduplicate the messages.
+	Key string
+	// Payload is the content of the message.
+	Payload []byte
+	// Headers are the metadata of the message.
+	Headers map[string]string
+}
+
+// OutboxRecord is a Message stored in an Outbox, waiting to be published.
+type OutboxRecord struct {
+	// ID is the unique identifier of the record in the Outbox.
+	ID string
+	// Message is the message to be published.
+	Message Message
+	// CreatedAt is the time the record was committed to the Outbox.
+	CreatedAt time.Time
+}
+
+// Outbox is the interface that wraps the methods of a transactional outbox. The messages of an action are
+// enqueued in a unit of work started by Begin, so an implementation backed by a database can write them in the
+// same transaction as the changes of the action and a crash can not lose them. The pending records are then
+// published by an OutboxRelay.
+type Outbox interface {
+	// Begin starts a unit of work. The returned context carries the unit of work, so the action can write its
+	// own changes in it, and the returned OutboxTx enqueues the messages in it.
+	Begin(ctx context.Context) (context.Context, OutboxTx, error)
+	// Pending returns up to limit records that were not published yet, oldest first.
+	Pending(ctx context.Context, limit int) ([]OutboxRecord, error)
+	// MarkPublished marks the records of the given IDs as published, so they are not pending anymore.
+	MarkPublished(ctx context.Context, ids ...string) error
+}
+
+// OutboxTx is the interface that wraps the methods of a unit of work of an Outbox.
+type OutboxTx interface {
+	// Enqueue adds the messages to the unit of work. They are only stored in the Outbox on Commit.
+	Enqueue(ctx context.Context, messages ...Message) error
+	// Commit stores the enqueued messages in the Outbox, along with any other change of the unit of work.
+	Commit() error
+	// Rollback discards the enqueued messages, along with any other change of the unit of work.
+	Rollback() error
+}
+
+// OutboxActionFn is a function that receives a context and the unit of work of an Outbox and returns an
+// error. It is the function of an action that enqueues outgoing messages.
+type OutboxActionFn func(ctx context.Context, tx OutboxTx) error
+
+// NewOutboxAction returns an ActionFn that runs the given function in a new unit of work of the Outbox. The
+// unit of work is committed if the function returns no error and rolled back otherwise, so the messages of a
+// failed attempt are never published and a retried step does not enqueue them twice. A panic will occur if the
+// outbox or the function are nil. Example:
+//
+//	outbox := sagas.NewMemoryOutbox()
+//
+//	step := sagas.NewStep("order", sagas.NewOutboxAction(outbox, func(ctx context.Context, tx sagas.OutboxTx) error {
+//		// save the order in the unit of work carried by ctx
+//		return tx.Enqueue(ctx, sagas.Message{Topic: "orders", Key: "42", Payload: []byte(`{"id":42}`)})
+//	}))
+//
+// The above example will create a step that saves an order and enqueues an event about it in the same unit of
+// work.
+func NewOutboxAction(outbox Outbox, fn OutboxActionFn) ActionFn {
+	if outbox == nil {
+		panic(errors.New("outbox cannot be nil"))
+	}
+
+	if fn == nil {
+		panic(errors.New("fn cannot be nil"))
+	}
+
+	return func(ctx context.Context) (err error) {
+		ctx, tx, err := outbox.Begin(ctx)
+		if err != nil {
+			return err
+		}
+
+		done := false
+		defer func() {
+			if !done {
+				_ = tx.Rollback()
+			}
+		}()
+
+		if err := fn(ctx, tx); err != nil {
+			done = true
+			return errors.Join(err, tx.Rollback())
+		}
+
+		done = true
+		return tx.Commit()
+	}
+}
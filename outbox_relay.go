@@ -0,0 +1,149 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Publisher is the interface that wraps the method to publish the records of an Outbox to a message broker.
+type Publisher interface {
+	// Publish delivers the record. It returns an error if the record was not delivered and should be retried.
+	Publish(ctx context.Context, record OutboxRecord) error
+}
+
+// PublisherFunc is a function that implements the Publisher interface.
+type PublisherFunc func(ctx context.Context, record OutboxRecord) error
+
+// Publish calls the function.
+func (f PublisherFunc) Publish(ctx context.Context, record OutboxRecord) error {
+	return f(ctx, record)
+}
+
+// OutboxRelay is the interface that wraps the methods to publish the pending records of an Outbox.
+type OutboxRelay interface {
+	// Run publishes the pending records until the context is done, polling the Outbox for new ones. It returns
+	// the error of the context.
+	Run(ctx context.Context) error
+	// Flush publishes the pending records once. It returns the error of the first record that could not be
+	// delivered, in which case the following records are left pending.
+	Flush(ctx context.Context) error
+}
+
+// outboxRelay is the concrete implementation of the OutboxRelay interface.
+type outboxRelay struct {
+	outbox    Outbox
+	publisher Publisher
+	retrier   Retrier
+	interval  time.Duration
+	batchSize int
+	logger    *slog.Logger
+	clock     Clock
+}
+
+// NewOutboxRelay returns a new OutboxRelay that publishes the pending records of the Outbox through the Publisher,
+// oldest first, retrying each delivery with its Retrier. The records are delivered at least once: a record
+// published right before a crash is published again, so the consumers should deduplicate them by ID or key. A
+// panic will occur if the outbox or the publisher are nil. Example:
+//
+//	relay := sagas.NewOutboxRelay(outbox, sagas.PublisherFunc(func(ctx context.Context, record sagas.OutboxRecord) error {
+//		return broker.Send(ctx, record.Message.Topic, record.Message.Payload)
+//	}), sagas.WithOutboxRelayInterval(500*time.Millisecond))
+//
+//	go relay.Run(ctx)
+//
+// The above example will create a relay that publishes the records of the outbox in the background.
+func NewOutboxRelay(outbox Outbox, publisher Publisher, options ...OutboxRelayOption) OutboxRelay {
+	if outbox == nil {
+		panic(errors.New("outbox cannot be nil"))
+	}
+
+	if publisher == nil {
+		panic(errors.New("publisher cannot be nil"))
+	}
+
+	relayOptions := newOutboxRelayOptions(options...)
+
+	return &outboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		retrier:   relayOptions.Retrier,
+		interval:  relayOptions.Interval,
+		batchSize: relayOptions.BatchSize,
+		logger:    relayOptions.Logger,
+		clock:     relayOptions.Clock,
+	}
+}
+
+// Run publishes the pending records until the context is done. A full batch is followed by the next one right
+// away, otherwise the relay waits for its interval before polling again.
+func (r *outboxRelay) Run(ctx context.Context) error {
+	ctx = withClock(withLogger(ctx, r.logger), r.clock)
+
+	for {
+		published, err := r.publishBatch(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err != nil {
+			logContext(ctx, slog.LevelError, "outbox delivery failed", slog.Any("error", err))
+		}
+
+		if err == nil && r.batchSize > 0 && published == r.batchSize {
+			continue
+		}
+
+		timer := r.clock.NewTimer(r.interval)
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Flush publishes batches of pending records until there are none left or a delivery fails.
+func (r *outboxRelay) Flush(ctx context.Context) error {
+	ctx = withClock(withLogger(ctx, r.logger), r.clock)
+
+	for {
+		published, err := r.publishBatch(ctx)
+		if err != nil {
+			return err
+		}
+
+		if r.batchSize <= 0 || published < r.batchSize {
+			return nil
+		}
+	}
+}
+
+// publishBatch publishes a batch of pending records in order, marking each one as published right after its
+// delivery. It stops at the first record that could not be delivered, so the order of the records is kept. It
+// returns the amount of records published.
+func (r *outboxRelay) publishBatch(ctx context.Context) (int, error) {
+	records, err := r.outbox.Pending(ctx, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, record := range records {
+		record := record
+		err := r.retrier.Retry(ctx, NewAction(func(ctx context.Context) error {
+			return r.publisher.Publish(ctx, record)
+		}))
+		if err != nil {
+			return i, fmt.Errorf("outbox record %s: %w", record.ID, err)
+		}
+
+		if err := r.outbox.MarkPublished(ctx, record.ID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(records), nil
+}
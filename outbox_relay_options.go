@@ -0,0 +1,71 @@
+package sagas
+
+import (
+	"log/slog"
+	"time"
+)
+
+type outboxRelayOptions struct {
+	Retrier   Retrier
+	Interval  time.Duration
+	BatchSize int
+	Logger    *slog.Logger
+	Clock     Clock
+}
+
+type OutboxRelayOption func(*outboxRelayOptions)
+
+func newOutboxRelayOptions(opts ...OutboxRelayOption) outboxRelayOptions {
+	options := outboxRelayOptions{
+		Retrier:   NewRetrier(BackoffExponential(3, 100*time.Millisecond, 2)),
+		Interval:  1 * time.Second,
+		BatchSize: 100,
+		Logger:    discardLogger,
+		Clock:     NewSystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// WithOutboxRelayRetrier sets the retrier used to deliver each record. By default, a record is retried three
+// times with an exponential backoff starting at 100ms.
+func WithOutboxRelayRetrier(retrier Retrier) OutboxRelayOption {
+	return func(o *outboxRelayOptions) {
+		o.Retrier = retrier
+	}
+}
+
+// WithOutboxRelayInterval sets the time the relay waits before polling the outbox again when there are no
+// pending records or a delivery failed. By default, it is one second.
+func WithOutboxRelayInterval(interval time.Duration) OutboxRelayOption {
+	return func(o *outboxRelayOptions) {
+		o.Interval = interval
+	}
+}
+
+// WithOutboxRelayBatchSize sets the maximum amount of records read from the outbox at once. By default, it is 100.
+func WithOutboxRelayBatchSize(size int) OutboxRelayOption {
+	return func(o *outboxRelayOptions) {
+		o.BatchSize = size
+	}
+}
+
+// WithOutboxRelayLogger sets the logger of the relay, used to log the failed deliveries. By default, nothing is
+// logged.
+func WithOutboxRelayLogger(logger *slog.Logger) OutboxRelayOption {
+	return func(o *outboxRelayOptions) {
+		o.Logger = logger
+	}
+}
+
+// WithOutboxRelayClock sets the clock used to wait between the polls of the outbox and given to the retrier of
+// the deliveries. By default, it is the clock of the system.
+func WithOutboxRelayClock(clock Clock) OutboxRelayOption {
+	return func(o *outboxRelayOptions) {
+		o.Clock = clock
+	}
+}
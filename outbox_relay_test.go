@@ -0,0 +1,156 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingPublisher struct {
+	failures map[string]int
+	keys     []string
+	mutex    sync.Mutex
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, record OutboxRecord) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.failures[record.Message.Key] > 0 {
+		p.failures[record.Message.Key]--
+		return assert.AnError
+	}
+	p.keys = append(p.keys, record.Message.Key)
+	return nil
+}
+
+func (p *recordingPublisher) published() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]string{}, p.keys...)
+}
+
+func Test_outboxRelay_Flush(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		failures      map[string]int
+		options       []OutboxRelayOption
+		wantErr       bool
+		wantPublished []string
+		wantPending   int
+	}{
+		{
+			name:          "[SUCCESS] Should publish every pending record in order",
+			options:       []OutboxRelayOption{WithOutboxRelayBatchSize(2)},
+			wantPublished: []string{"a", "b", "c"},
+		},
+
+		{
+			name:     "[SUCCESS] Should retry a failed delivery",
+			failures: map[string]int{"b": 1},
+			options: []OutboxRelayOption{
+				WithOutboxRelayRetrier(NewRetrier(BackoffConstant(1, 1*time.Millisecond))),
+			},
+			wantPublished: []string{"a", "b", "c"},
+		},
+
+		{
+			name:     "[FAILURE] Should stop at the first record that could not be delivered",
+			failures: map[string]int{"b": 5},
+			options: []OutboxRelayOption{
+				WithOutboxRelayRetrier(NewRetrier(BackoffConstant(1, 1*time.Millisecond))),
+			},
+			wantErr:       true,
+			wantPublished: []string{"a"},
+			wantPending:   2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				outbox := NewMemoryOutbox()
+				commitMessages(t, outbox, Message{Key: "a"}, Message{Key: "b"}, Message{Key: "c"})
+				publisher := &recordingPublisher{failures: test.failures}
+				relay := NewOutboxRelay(outbox, publisher, test.options...)
+
+				err := relay.Flush(context.Background())
+
+				if test.wantErr {
+					assert.ErrorIs(t, err, assert.AnError)
+				} else {
+					assert.NoError(t, err)
+				}
+				assert.Equal(t, test.wantPublished, publisher.published())
+				pending, _ := outbox.Pending(context.Background(), 0)
+				assert.Len(t, pending, test.wantPending)
+			})
+		})
+	}
+}
+
+func Test_outboxRelay_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should publish the records committed while running until the context is done", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			outbox := NewMemoryOutbox()
+			publisher := &recordingPublisher{}
+			relay := NewOutboxRelay(outbox, publisher, WithOutboxRelayInterval(1*time.Millisecond))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+			go func() { done <- relay.Run(ctx) }()
+
+			commitMessages(t, outbox, Message{Key: "a"})
+			assert.Eventually(t, func() bool { return len(publisher.published()) == 1 }, time.Second, time.Millisecond)
+			commitMessages(t, outbox, Message{Key: "b"})
+			assert.Eventually(t, func() bool { return len(publisher.published()) == 2 }, time.Second, time.Millisecond)
+
+			cancel()
+			assert.ErrorIs(t, <-done, context.Canceled)
+			assert.Equal(t, []string{"a", "b"}, publisher.published())
+		})
+	})
+}
+
+func Test_NewOutboxRelay_Panics(t *testing.T) {
+	t.Parallel()
+
+	publisher := PublisherFunc(func(context.Context, OutboxRecord) error { return nil })
+
+	tests := []struct {
+		name      string
+		outbox    Outbox
+		publisher Publisher
+	}{
+		{
+			name:      "[FAILURE] Should panic with a nil outbox",
+			outbox:    nil,
+			publisher: publisher,
+		},
+
+		{
+			name:      "[FAILURE] Should panic with a nil publisher",
+			outbox:    NewMemoryOutbox(),
+			publisher: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Panics(t, func() {
+				NewOutboxRelay(test.outbox, test.publisher)
+			})
+		})
+	}
+}
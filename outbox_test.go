@@ -0,0 +1,126 @@
+package sagas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewOutboxAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		fn          OutboxActionFn
+		wantErr     bool
+		wantPending int
+	}{
+		{
+			name: "[SUCCESS] Should commit the messages of a successful action",
+			fn: func(ctx context.Context, tx OutboxTx) error {
+				return tx.Enqueue(ctx, Message{Topic: "orders"}, Message{Topic: "payments"})
+			},
+			wantErr:     false,
+			wantPending: 2,
+		},
+
+		{
+			name: "[FAILURE] Should roll back the messages of a failed action",
+			fn: func(ctx context.Context, tx OutboxTx) error {
+				_ = tx.Enqueue(ctx, Message{Topic: "orders"})
+				return assert.AnError
+			},
+			wantErr:     true,
+			wantPending: 0,
+		},
+
+		{
+			name: "[FAILURE] Should roll back the messages of a panicking action",
+			fn: func(ctx context.Context, tx OutboxTx) error {
+				_ = tx.Enqueue(ctx, Message{Topic: "orders"})
+				panic("boom")
+			},
+			wantErr:     true,
+			wantPending: 0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				outbox := NewMemoryOutbox()
+				s := NewStep("test", NewOutboxAction(outbox, test.fn))
+
+				err := s.Run(context.Background())
+
+				assert.Equal(t, test.wantErr, err != nil)
+				pending, _ := outbox.Pending(context.Background(), 0)
+				assert.Len(t, pending, test.wantPending)
+			})
+		})
+	}
+}
+
+func Test_NewOutboxAction_WithRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should only commit the messages of the successful attempt", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			outbox := NewMemoryOutbox()
+			calls := 0
+			s := NewStep("test", NewOutboxAction(outbox, func(ctx context.Context, tx OutboxTx) error {
+				calls++
+				_ = tx.Enqueue(ctx, Message{Topic: "orders"})
+				if calls == 1 {
+					return assert.AnError
+				}
+				return nil
+			}), WithStepRetrier(NewRetrier(BackoffConstant(1, 1*time.Millisecond))))
+
+			err := s.Run(context.Background())
+
+			assert.NoError(t, err)
+			pending, _ := outbox.Pending(context.Background(), 0)
+			assert.Len(t, pending, 1)
+		})
+	})
+}
+
+func Test_NewOutboxAction_Panics(t *testing.T) {
+	t.Parallel()
+
+	fn := func(ctx context.Context, tx OutboxTx) error { return nil }
+
+	tests := []struct {
+		name   string
+		outbox Outbox
+		fn     OutboxActionFn
+	}{
+		{
+			name:   "[FAILURE] Should panic with a nil outbox",
+			outbox: nil,
+			fn:     fn,
+		},
+
+		{
+			name:   "[FAILURE] Should panic with a nil function",
+			outbox: NewMemoryOutbox(),
+			fn:     nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Panics(t, func() {
+				NewOutboxAction(test.outbox, test.fn)
+			})
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package sagas
+
+import "context"
+
+// outputRecorder is the interface implemented by the Step to keep the output set by its action. It travels
+// through the context given to the action.
+type outputRecorder interface {
+	// setOutput replaces the output of the Step.
+	setOutput(output []byte)
+}
+
+// outputRecorderKey is the context key of the outputRecorder.
+type outputRecorderKey struct{}
+
+// withOutputRecorder returns a copy of the context that carries the given outputRecorder.
+func withOutputRecorder(ctx context.Context, recorder outputRecorder) context.Context {
+	return context.WithValue(ctx, outputRecorderKey{}, recorder)
+}
+
+// SetOutput sets the output of the step whose action is running with the given context, replacing any
+// previous one. The output is returned by the Output method of the step and kept by its IdempotencyStore, so
+// it is restored when the step is not run again. It does nothing if the context does not belong to a step.
+// Example:
+//
+//	step := sagas.NewStep("charge", func(ctx context.Context) error {
+//		receipt, err := payments.Charge(ctx, order)
+//		if err != nil {
+//			return err
+//		}
+//		sagas.SetOutput(ctx, []byte(receipt.ID))
+//		return nil
+//	})
+//
+// The above example will keep the receipt of the charge as the output of the step.
+func SetOutput(ctx context.Context, output []byte) {
+	if recorder, ok := ctx.Value(outputRecorderKey{}).(outputRecorder); ok {
+		recorder.setOutput(output)
+	}
+}
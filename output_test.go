@@ -0,0 +1,61 @@
+package sagas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		outputs [][]byte
+		want    []byte
+	}{
+		{
+			name:    "[SUCCESS] Should set the output of the step",
+			outputs: [][]byte{[]byte("receipt")},
+			want:    []byte("receipt"),
+		},
+
+		{
+			name:    "[SUCCESS] Should replace the previous output",
+			outputs: [][]byte{[]byte("first"), []byte("second")},
+			want:    []byte("second"),
+		},
+
+		{
+			name:    "[SUCCESS] Should have no output if none is set",
+			outputs: nil,
+			want:    nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				s := NewStep("test", func(ctx context.Context) error {
+					for _, output := range test.outputs {
+						SetOutput(ctx, output)
+					}
+					return nil
+				})
+
+				assert.NoError(t, s.Run(context.Background()))
+				assert.Equal(t, test.want, s.Output())
+			})
+		})
+	}
+
+	t.Run("[SUCCESS] Should do nothing outside of a step", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			SetOutput(context.Background(), []byte("receipt"))
+		})
+	})
+}
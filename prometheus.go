@@ -0,0 +1,275 @@
+package sagas
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the histogram buckets used by NewPrometheusMetrics when no
+// buckets are given.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// PrometheusMetrics is the interface of a MetricsRecorder that exposes the recorded metrics in the Prometheus
+// text exposition format, either written to an io.Writer or served through HTTP.
+type PrometheusMetrics interface {
+	MetricsRecorder
+	io.WriterTo
+	http.Handler
+}
+
+// prometheusMetrics is the concrete implementation of the PrometheusMetrics interface.
+type prometheusMetrics struct {
+	sagaStarted       *metricFamily
+	sagaCompleted     *metricFamily
+	sagaDuration      *metricFamily
+	sagaCompensations *metricFamily
+	stepDuration      *metricFamily
+	stepAttempts      *metricFamily
+	stepRetries       *metricFamily
+	actionQueueDelay  *metricFamily
+	families          []*metricFamily
+	mutex             sync.Mutex
+}
+
+// NewPrometheusMetrics returns a new MetricsRecorder that keeps its metrics in memory and exposes them in the
+// Prometheus text exposition format. The histograms use the given bucket upper bounds, in seconds, or the
+// DefaultBuckets if none are given. The metrics are labeled by the names of the sagas and steps, without the
+// unique suffix of their identifiers. Example:
+//
+//	metrics := sagas.NewPrometheusMetrics()
+//
+//	saga := sagas.NewSaga(sagas.WithSagaName("checkout"), sagas.WithSagaMetrics(metrics))
+//
+//	http.Handle("/metrics", metrics)
+//
+// The above example will create a new saga whose metrics are served at the /metrics endpoint.
+func NewPrometheusMetrics(buckets ...float64) PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	m := &prometheusMetrics{
+		sagaStarted: newMetricFamily("sagas_saga_started_total", "Number of sagas started.",
+			"counter", nil, "saga"),
+		sagaCompleted: newMetricFamily("sagas_saga_completed_total", "Number of sagas completed.",
+			"counter", nil, "saga"),
+		sagaDuration: newMetricFamily("sagas_saga_duration_seconds", "Duration of the sagas in seconds.",
+			"histogram", buckets, "saga"),
+		sagaCompensations: newMetricFamily("sagas_saga_compensations_total", "Number of compensation steps run by the sagas.",
+			"counter", nil, "saga", "step"),
+		stepDuration: newMetricFamily("sagas_step_duration_seconds", "Duration of the steps in seconds by final status.",
+			"histogram", buckets, "step", "status"),
+		stepAttempts: newMetricFamily("sagas_step_attempts_total", "Number of attempts of the steps by error classification.",
+			"counter", nil, "step", "status"),
+		stepRetries: newMetricFamily("sagas_step_retries_total", "Number of retries of the steps.",
+			"counter", nil, "step"),
+		actionQueueDelay: newMetricFamily("sagas_action_queue_delay_seconds", "Time the actions of the sagas waited for a worker in seconds.",
+			"histogram", buckets, "saga"),
+	}
+
+	m.families = []*metricFamily{
+		m.sagaStarted, m.sagaCompleted, m.sagaDuration, m.sagaCompensations,
+		m.stepDuration, m.stepAttempts, m.stepRetries, m.actionQueueDelay,
+	}
+
+	return m
+}
+
+// SagaStarted increments the number of sagas started.
+func (m *prometheusMetrics) SagaStarted(saga Identifier) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sagaStarted.add(1, IdentifierName(saga))
+}
+
+// SagaCompleted increments the number of sagas completed and observes their duration.
+func (m *prometheusMetrics) SagaCompleted(saga Identifier, duration time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sagaCompleted.add(1, IdentifierName(saga))
+	m.sagaDuration.observe(duration.Seconds(), IdentifierName(saga))
+}
+
+// SagaCompensated increments the number of compensation steps run by the saga.
+func (m *prometheusMetrics) SagaCompensated(saga Identifier, step Identifier) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sagaCompensations.add(1, IdentifierName(saga), IdentifierName(step))
+}
+
+// StepFinished observes the duration of the step by its final status.
+func (m *prometheusMetrics) StepFinished(step Identifier, status Status, duration time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.stepDuration.observe(duration.Seconds(), IdentifierName(step), status.String())
+}
+
+// AttemptFinished increments the number of attempts by classification and the number of retries.
+func (m *prometheusMetrics) AttemptFinished(step Identifier, attempt Attempt, retrying bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.stepAttempts.add(1, IdentifierName(step), attempt.Status.String())
+	if retrying {
+		m.stepRetries.add(1, IdentifierName(step))
+	}
+}
+
+// ActionQueued observes the time an action of the saga waited for a worker.
+func (m *prometheusMetrics) ActionQueued(saga Identifier, delay time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.actionQueueDelay.observe(delay.Seconds(), IdentifierName(saga))
+}
+
+// WriteTo writes the metrics to the writer in the Prometheus text exposition format.
+func (m *prometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	buffer := bytes.Buffer{}
+
+	m.mutex.Lock()
+	for _, family := range m.families {
+		family.write(&buffer)
+	}
+	m.mutex.Unlock()
+
+	return buffer.WriteTo(w)
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (m *prometheusMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = m.WriteTo(w)
+}
+
+// metricFamily is a metric with all its labeled series.
+type metricFamily struct {
+	name    string
+	help    string
+	kind    string
+	buckets []float64
+	labels  []string
+	series  map[string]*metricSeries
+}
+
+// metricSeries is a labeled series of a metric. Counters only use the value, while histograms use the bucket
+// counts, the sum and the count.
+type metricSeries struct {
+	values []string
+	value  float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// newMetricFamily returns a new metricFamily with no series.
+func newMetricFamily(name string, help string, kind string, buckets []float64, labels ...string) *metricFamily {
+	return &metricFamily{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		buckets: buckets,
+		labels:  labels,
+		series:  make(map[string]*metricSeries),
+	}
+}
+
+// get returns the series of the given label values, creating it if it does not exist.
+func (f *metricFamily) get(values ...string) *metricSeries {
+	key := strings.Join(values, "\xff")
+	if s, ok := f.series[key]; ok {
+		return s
+	}
+
+	s := &metricSeries{
+		values: values,
+		counts: make([]uint64, len(f.buckets)),
+	}
+	f.series[key] = s
+	return s
+}
+
+// add adds the value to the counter of the given label values.
+func (f *metricFamily) add(value float64, values ...string) {
+	f.get(values...).value += value
+}
+
+// observe records the value in the histogram of the given label values.
+func (f *metricFamily) observe(value float64, values ...string) {
+	s := f.get(values...)
+	for i, bound := range f.buckets {
+		if value <= bound {
+			s.counts[i]++
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// write writes the family in the Prometheus text exposition format. The series are sorted by their labels.
+func (f *metricFamily) write(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, f.help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+
+	series := make([]*metricSeries, 0, len(f.series))
+	for _, s := range f.series {
+		series = append(series, s)
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return strings.Join(series[i].values, "\xff") < strings.Join(series[j].values, "\xff")
+	})
+
+	for _, s := range series {
+		labels := formatLabels(f.labels, s.values)
+		if f.kind != "histogram" {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, labels, formatFloat(s.value))
+			continue
+		}
+
+		names := append(append([]string(nil), f.labels...), "le")
+		values := append(append([]string(nil), s.values...), "")
+		for i, bound := range f.buckets {
+			values[len(values)-1] = formatFloat(bound)
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(names, values), s.counts[i])
+		}
+		values[len(values)-1] = "+Inf"
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(names, values), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.name, labels, formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.name, labels, s.count)
+	}
+}
+
+// formatLabels returns the labels in the Prometheus text exposition format, escaping their values.
+func formatLabels(names []string, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + replacer.Replace(values[i]) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// formatFloat returns the value in the Prometheus text exposition format.
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
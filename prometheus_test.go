@@ -0,0 +1,141 @@
+package sagas
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_prometheusMetrics_WriteTo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		record func(m PrometheusMetrics)
+		want   []string
+	}{
+		{
+			name: "[SUCCESS] Should write the saga counters and histogram",
+			record: func(m PrometheusMetrics) {
+				saga := NewIdentifier("checkout")
+				m.SagaStarted(saga)
+				m.SagaCompleted(saga, 2*time.Second)
+			},
+			want: []string{
+				`sagas_saga_started_total{saga="checkout"} 1`,
+				`sagas_saga_completed_total{saga="checkout"} 1`,
+				`sagas_saga_duration_seconds_bucket{saga="checkout",le="1"} 0`,
+				`sagas_saga_duration_seconds_bucket{saga="checkout",le="5"} 1`,
+				`sagas_saga_duration_seconds_bucket{saga="checkout",le="+Inf"} 1`,
+				`sagas_saga_duration_seconds_sum{saga="checkout"} 2`,
+				`sagas_saga_duration_seconds_count{saga="checkout"} 1`,
+			},
+		},
+
+		{
+			name: "[SUCCESS] Should write the step attempts, retries and compensations",
+			record: func(m PrometheusMetrics) {
+				step := NewIdentifier("payment")
+				m.AttemptFinished(step, Attempt{Status: Retry}, true)
+				m.AttemptFinished(step, Attempt{Status: Successed}, false)
+				m.StepFinished(step, Successed, 100*time.Millisecond)
+				m.SagaCompensated(NewIdentifier("checkout"), NewIdentifier("refund"))
+			},
+			want: []string{
+				`sagas_step_attempts_total{step="payment",status="Retry"} 1`,
+				`sagas_step_attempts_total{step="payment",status="Successed"} 1`,
+				`sagas_step_retries_total{step="payment"} 1`,
+				`sagas_step_duration_seconds_count{step="payment",status="Successed"} 1`,
+				`sagas_saga_compensations_total{saga="checkout",step="refund"} 1`,
+			},
+		},
+
+		{
+			name: "[SUCCESS] Should write the queueing delay of the actions",
+			record: func(m PrometheusMetrics) {
+				m.(QueueMetricsRecorder).ActionQueued(NewIdentifier("checkout"), 30*time.Millisecond)
+			},
+			want: []string{
+				`sagas_action_queue_delay_seconds_bucket{saga="checkout",le="0.025"} 0`,
+				`sagas_action_queue_delay_seconds_bucket{saga="checkout",le="0.05"} 1`,
+				`sagas_action_queue_delay_seconds_count{saga="checkout"} 1`,
+			},
+		},
+
+		{
+			name: "[SUCCESS] Should escape the label values",
+			record: func(m PrometheusMetrics) {
+				m.SagaStarted(NewIdentifier("a\"b\\c"))
+			},
+			want: []string{
+				`sagas_saga_started_total{saga="a\"b\\c"} 1`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				m := NewPrometheusMetrics()
+				test.record(m)
+
+				buffer := bytes.Buffer{}
+				n, err := m.WriteTo(&buffer)
+
+				assert.NoError(t, err)
+				assert.Equal(t, int64(buffer.Len()), n)
+				lines := strings.Split(buffer.String(), "\n")
+				for _, want := range test.want {
+					assert.Contains(t, lines, want)
+				}
+			})
+		})
+	}
+}
+
+func Test_prometheusMetrics_WriteTo_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should write the series sorted by their labels", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			m := NewPrometheusMetrics(1)
+			m.SagaStarted(NewIdentifier("b"))
+			m.SagaStarted(NewIdentifier("a"))
+
+			first, second := bytes.Buffer{}, bytes.Buffer{}
+			_, _ = m.WriteTo(&first)
+			_, _ = m.WriteTo(&second)
+
+			assert.Equal(t, first.String(), second.String())
+			assert.Less(t, strings.Index(first.String(), `{saga="a"}`), strings.Index(first.String(), `{saga="b"}`))
+		})
+	})
+}
+
+func Test_prometheusMetrics_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should serve the metrics in the text exposition format", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			m := NewPrometheusMetrics()
+			m.SagaStarted(NewIdentifier("checkout"))
+
+			recorder := httptest.NewRecorder()
+			m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Contains(t, recorder.Header().Get("Content-Type"), "text/plain")
+			assert.Contains(t, recorder.Body.String(), "# TYPE sagas_saga_started_total counter")
+			assert.Contains(t, recorder.Body.String(), `sagas_saga_started_total{saga="checkout"} 1`)
+		})
+	})
+}
@@ -0,0 +1,35 @@
+package sagas
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// random is a pseudo-random number generator that is safe for concurrent use. It is used to randomize the
+// back-off delays.
+type random struct {
+	// rand is the underlying pseudo-random number generator.
+	rand *rand.Rand
+	// mutex is used to protect the pseudo-random number generator.
+	mutex sync.Mutex
+}
+
+// newRandom returns a new random seeded with the given seed.
+func newRandom(seed int64) *random {
+	return &random{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// newTimeRandom returns a new random seeded with the current time.
+func newTimeRandom() *random {
+	return newRandom(time.Now().UnixNano())
+}
+
+// float64 returns a pseudo-random number in the range [0.0,1.0).
+func (r *random) float64() float64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.rand.Float64()
+}
@@ -0,0 +1,37 @@
+package sagas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_random_float64(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		seed int64
+	}{
+		{
+			name: "[SUCCESS] Should return the same sequence for the same seed",
+			seed: 42,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				a, b := newRandom(test.seed), newRandom(test.seed)
+				for i := 0; i < 10; i++ {
+					got := a.float64()
+					assert.Equal(t, got, b.float64())
+					assert.GreaterOrEqual(t, got, 0.0)
+					assert.Less(t, got, 1.0)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned by a fail-fast RateLimiter when there is no capacity left to perform an attempt.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimitMode determines what a RateLimiter does when there is no capacity left. It can be one of the
+// following: RateLimitBlock, RateLimitFailFast.
+type RateLimitMode int
+
+const (
+	// RateLimitBlock indicates that the RateLimiter should wait until there is capacity to perform the attempt
+	// or the context is done.
+	RateLimitBlock RateLimitMode = iota
+	// RateLimitFailFast indicates that the RateLimiter should return ErrRateLimited immediately.
+	RateLimitFailFast
+)
+
+// RateLimiter is the interface that wraps the method to cap the amount of attempts per second. A single
+// RateLimiter can be shared across many Retriers and Steps, capping the attempts of every saga instance.
+type RateLimiter interface {
+	// Acquire takes the permission to perform an attempt. It returns an error if the attempt should not be
+	// performed, either because the context is done or because the limit is exceeded.
+	Acquire(context.Context) error
+}
+
+// rateLimiter is a token bucket implementation of the RateLimiter interface.
+type rateLimiter struct {
+	// rate is the amount of tokens refilled per second.
+	rate float64
+	// burst is the maximum amount of tokens the bucket can hold.
+	burst float64
+	// tokens is the current amount of tokens in the bucket. It is negative when there are waiting attempts.
+	tokens float64
+	// last is the last time the bucket was refilled.
+	last time.Time
+	// mode determines what to do when there are no tokens left.
+	mode RateLimitMode
+	// clock is used to refill the bucket and to wait for the tokens.
+	clock Clock
+	// mutex is used to protect the bucket.
+	mutex sync.Mutex
+}
+
+// NewRateLimiter constructs a RateLimiter that allows rate attempts per second with bursts of up to burst
+// attempts. The mode determines if Acquire waits for capacity or fails fast with ErrRateLimited. A panic will
+// occur if the rate or the burst are not positive. Example:
+//
+//	limiter := sagas.NewRateLimiter(50, 10, sagas.RateLimitBlock)
+//
+//	retrier := sagas.NewRetrier(sagas.BackoffConstant(3, 1*time.Second), sagas.WithRetrierRateLimiter(limiter))
+//
+// The above example creates a RateLimiter that caps the attempts of every Retrier sharing it to 50 per second,
+// waiting for capacity when the limit is reached. The WithRateLimiterClock option sets the clock of the limiter.
+func NewRateLimiter(rate float64, burst int, mode RateLimitMode, options ...RateLimiterOption) RateLimiter {
+	if rate <= 0 {
+		panic("rate must be positive")
+	}
+
+	if burst <= 0 {
+		panic("burst must be positive")
+	}
+
+	limiterOptions := newRateLimiterOptions(options...)
+
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   limiterOptions.Clock.Now(),
+		mode:   mode,
+		clock:  limiterOptions.Clock,
+	}
+}
+
+// Acquire takes a token from the bucket. If there are no tokens left, it either returns ErrRateLimited or
+// reserves the next token and waits for it, returning early if the context is canceled.
+func (l *rateLimiter) Acquire(ctx context.Context) error {
+	l.mutex.Lock()
+	l.refill(l.clock.Now())
+
+	if l.tokens >= 1 {
+		l.tokens--
+		l.mutex.Unlock()
+		return nil
+	}
+
+	if l.mode == RateLimitFailFast {
+		l.mutex.Unlock()
+		return ErrRateLimited
+	}
+
+	wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	l.tokens--
+	l.mutex.Unlock()
+
+	timer := l.clock.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		l.tokens++
+		l.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+// refill adds the tokens produced since the last refill, up to the burst.
+func (l *rateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	l.tokens = min(l.burst, l.tokens+elapsed*l.rate)
+}
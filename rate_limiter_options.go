@@ -0,0 +1,27 @@
+package sagas
+
+type rateLimiterOptions struct {
+	Clock Clock
+}
+
+type RateLimiterOption func(*rateLimiterOptions)
+
+func newRateLimiterOptions(opts ...RateLimiterOption) rateLimiterOptions {
+	options := rateLimiterOptions{
+		Clock: NewSystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// WithRateLimiterClock sets the clock used to refill the bucket and to wait for capacity. By default, it is the
+// clock of the system.
+func WithRateLimiterClock(clock Clock) RateLimiterOption {
+	return func(o *rateLimiterOptions) {
+		o.Clock = clock
+	}
+}
@@ -0,0 +1,170 @@
+package sagas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		rate  float64
+		burst int
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		shouldPanic bool
+	}{
+		{
+			name: "[SUCCESS] Should return a new RateLimiter",
+			args: args{
+				rate:  10,
+				burst: 1,
+			},
+		},
+
+		{
+			name: "[PANIC] Should panic if the rate is not positive",
+			args: args{
+				rate:  0,
+				burst: 1,
+			},
+			shouldPanic: true,
+		},
+
+		{
+			name: "[PANIC] Should panic if the burst is not positive",
+			args: args{
+				rate:  10,
+				burst: 0,
+			},
+			shouldPanic: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if test.shouldPanic {
+				assert.Panics(t, func() {
+					NewRateLimiter(test.args.rate, test.args.burst, RateLimitBlock)
+				})
+				return
+			}
+			assert.NotPanics(t, func() {
+				got := NewRateLimiter(test.args.rate, test.args.burst, RateLimitBlock)
+				assert.NotNil(t, got)
+			})
+		})
+	}
+}
+
+func Test_rateLimiter_Acquire(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		rate     float64
+		burst    int
+		mode     RateLimitMode
+		acquires int
+		ctx      func() (context.Context, context.CancelFunc)
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		minElapsed    time.Duration
+		expectedError string
+	}{
+		{
+			name: "[SUCCESS] Should acquire within the burst without waiting",
+			args: args{
+				rate:     1,
+				burst:    3,
+				mode:     RateLimitFailFast,
+				acquires: 3,
+				ctx:      func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			},
+		},
+
+		{
+			name: "[SUCCESS] Should wait for capacity when blocking",
+			args: args{
+				rate:     20,
+				burst:    1,
+				mode:     RateLimitBlock,
+				acquires: 2,
+				ctx:      func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			},
+			minElapsed: 40 * time.Millisecond,
+		},
+
+		{
+			name: "[ERROR] Should fail fast when the limit is exceeded",
+			args: args{
+				rate:     1,
+				burst:    1,
+				mode:     RateLimitFailFast,
+				acquires: 2,
+				ctx:      func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			},
+			expectedError: ErrRateLimited.Error(),
+		},
+
+		{
+			name: "[ERROR] Should stop waiting when the context is done",
+			args: args{
+				rate:     0.1,
+				burst:    1,
+				mode:     RateLimitBlock,
+				acquires: 2,
+				ctx: func() (context.Context, context.CancelFunc) {
+					return context.WithTimeout(context.Background(), 10*time.Millisecond)
+				},
+			},
+			expectedError: "context deadline exceeded",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				ctx, cancel := test.args.ctx()
+				defer cancel()
+				l := NewRateLimiter(test.args.rate, test.args.burst, test.args.mode)
+				start := time.Now()
+				var err error
+				for i := 0; i < test.args.acquires && err == nil; i++ {
+					err = l.Acquire(ctx)
+				}
+				if test.expectedError == "" {
+					assert.NoError(t, err)
+					assert.GreaterOrEqual(t, time.Since(start), test.minElapsed)
+				} else {
+					assert.Equal(t, test.expectedError, err.Error())
+				}
+			})
+		})
+	}
+}
+
+func Test_rateLimiter_Acquire_WithClock(t *testing.T) {
+	t.Parallel()
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	limiter := NewRateLimiter(1, 1, RateLimitFailFast, WithRateLimiterClock(clock))
+
+	assert.NoError(t, limiter.Acquire(context.Background()))
+	assert.ErrorIs(t, limiter.Acquire(context.Background()), ErrRateLimited)
+
+	clock.Advance(time.Second)
+	assert.NoError(t, limiter.Acquire(context.Background()))
+}
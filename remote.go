@@ -0,0 +1,135 @@
+package sagas
+
+import (
+	"context"
+	"log/slog"
+)
+
+// remoteNotifier is a Notifier that publishes the notifications through a Transport, besides notifying its
+// local observers.
+type remoteNotifier struct {
+	Notifier
+	transport Transport
+	topic     string
+	codec     Codec
+	logger    *slog.Logger
+}
+
+// NewRemoteNotifier returns a new Notifier that publishes every notification to the topic of the Transport, so
+// that the steps of other services can be triggered by it. The observers added to it are notified locally as
+// well. A panic will occur if the transport is nil or the topic is empty. Example:
+//
+//	transport := sagas.NewChannelTransport(16)
+//
+//	saga := sagas.NewSaga(sagas.WithSagaNotifier(sagas.NewRemoteNotifier(transport, "orders")))
+//
+// The above example will create a saga that publishes the events of its steps to the "orders" topic.
+func NewRemoteNotifier(transport Transport, topic string, options ...RemoteOption) Notifier {
+	if transport == nil {
+		panic("transport can not be nil")
+	}
+
+	if topic == "" {
+		panic("topic can not be empty")
+	}
+
+	remoteOptions := newRemoteOptions(options...)
+
+	return &remoteNotifier{
+		Notifier:  NewNotifier(),
+		transport: transport,
+		topic:     topic,
+		codec:     remoteOptions.Codec,
+		logger:    remoteOptions.Logger,
+	}
+}
+
+// Notify notifies the local observers and publishes the notification. A notification that could not be
+// published is logged.
+func (n *remoteNotifier) Notify(ctx context.Context, notification Notification) {
+	n.Notifier.Notify(ctx, notification)
+
+	if n.logger != nil {
+		ctx = withLogger(ctx, n.logger)
+	}
+
+	data, err := n.codec.Encode(notification)
+	if err == nil {
+		err = n.transport.Publish(ctx, n.topic, data)
+	}
+
+	if err != nil {
+		logContext(withNotification(ctx, notification), slog.LevelError, "remote notification failed",
+			slog.String("topic", n.topic), slog.Any("error", err))
+	}
+}
+
+// RemoteObserver is the interface of an Observer that receives the notifications of other services through a
+// Transport and executes them through an execution plan.
+type RemoteObserver interface {
+	Observer
+	// Listen subscribes to the topic and executes the received notifications until the context is done. It
+	// does not block.
+	Listen(ctx context.Context) error
+}
+
+// remoteObserver is the concrete implementation of the RemoteObserver interface.
+type remoteObserver struct {
+	Observer
+	transport Transport
+	topic     string
+	codec     Codec
+	logger    *slog.Logger
+}
+
+// NewRemoteObserver returns a new RemoteObserver that executes the notifications received from the topic of
+// the Transport through the execution plan. The transitions on steps of other services are planned with their
+// stable identifiers. A panic will occur if the transport or the execution plan are nil or the topic is empty.
+// Example:
+//
+//	plan := sagas.NewExecutionPlan()
+//
+//	plan.Add(sagas.Notification{Identifier: sagas.NewStableIdentifier("orders.create"), Event: sagas.Successed}, chargeAction)
+//
+//	observer := sagas.NewRemoteObserver(transport, "orders", plan)
+//
+//	err := observer.Listen(ctx)
+//
+// The above example will run the charge action whenever the create step of the orders service succeeds.
+func NewRemoteObserver(transport Transport, topic string, executionPlan ExecutionPlan, options ...RemoteOption) RemoteObserver {
+	if transport == nil {
+		panic("transport can not be nil")
+	}
+
+	if topic == "" {
+		panic("topic can not be empty")
+	}
+
+	remoteOptions := newRemoteOptions(options...)
+
+	return &remoteObserver{
+		Observer:  NewObserver(executionPlan),
+		transport: transport,
+		topic:     topic,
+		codec:     remoteOptions.Codec,
+		logger:    remoteOptions.Logger,
+	}
+}
+
+// Listen subscribes to the topic of the Transport. The messages that could not be decoded are logged and
+// dropped.
+func (o *remoteObserver) Listen(ctx context.Context) error {
+	if o.logger != nil {
+		ctx = withLogger(ctx, o.logger)
+	}
+
+	return o.transport.Subscribe(ctx, o.topic, func(ctx context.Context, data []byte) {
+		notification, err := o.codec.Decode(data)
+		if err != nil {
+			logContext(ctx, slog.LevelError, "remote notification dropped",
+				slog.String("topic", o.topic), slog.Any("error", err))
+			return
+		}
+		o.Execute(ctx, notification)
+	})
+}
@@ -0,0 +1,38 @@
+package sagas
+
+import "log/slog"
+
+type remoteOptions struct {
+	Codec  Codec
+	Logger *slog.Logger
+}
+
+type RemoteOption func(*remoteOptions)
+
+func newRemoteOptions(opts ...RemoteOption) remoteOptions {
+	options := remoteOptions{
+		Codec:  NewJSONCodec(),
+		Logger: nil,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// WithRemoteCodec sets the codec used to serialize the notifications. By default, they are serialized as JSON.
+func WithRemoteCodec(codec Codec) RemoteOption {
+	return func(o *remoteOptions) {
+		o.Codec = codec
+	}
+}
+
+// WithRemoteLogger sets the logger used to log the notifications that could not be published or decoded. By
+// default, the logger carried by the context is used.
+func WithRemoteLogger(logger *slog.Logger) RemoteOption {
+	return func(o *remoteOptions) {
+		o.Logger = logger
+	}
+}
@@ -0,0 +1,125 @@
+package sagas
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_remoteNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should publish the notification and notify the local observers", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			transport := NewChannelTransport(1)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			received := &receivedMessages{}
+			assert.NoError(t, transport.Subscribe(ctx, "orders", received.handle))
+
+			local := make(chan Notification, 1)
+			notifier := NewRemoteNotifier(transport, "orders")
+			notifier.Add(observerFunc(func(_ context.Context, n Notification) { local <- n }))
+
+			notification, _ := NewNotification(NewStableIdentifier("orders.create"), Successed)
+			notifier.Notify(ctx, notification)
+
+			assert.Equal(t, notification, <-local)
+			assert.Eventually(t, func() bool { return len(received.received()) == 1 }, time.Second, time.Millisecond)
+			got, err := NewJSONCodec().Decode([]byte(received.received()[0]))
+			assert.NoError(t, err)
+			assert.Equal(t, notification, got)
+		})
+	})
+}
+
+func Test_remoteObserver_Listen(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should trigger the step of another saga through the transport", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			transport := NewChannelTransport(4)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var charged atomic.Bool
+			charge := NewStep("payments.charge", func(ctx context.Context) error {
+				charged.Store(true)
+				return nil
+			})
+
+			plan := NewExecutionPlan()
+			plan.Add(Notification{Identifier: NewStableIdentifier("orders.create"), Event: Successed},
+				NewAction(charge.Run))
+			assert.NoError(t, NewRemoteObserver(transport, "orders", plan).Listen(ctx))
+
+			create := NewStep("orders.create", makeActionNoError(context.Background()),
+				WithStepIdentifier(NewStableIdentifier("orders.create")))
+			orders := NewSaga(WithSagaNotifier(NewRemoteNotifier(transport, "orders")))
+			orders.AddSteps(create)
+			orders.Run(ctx, func() bool { return create.GetState() == Completed })
+
+			assert.Eventually(t, charged.Load, time.Second, time.Millisecond)
+		})
+	})
+
+	t.Run("[FAILURE] Should drop the messages that could not be decoded", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			transport := NewChannelTransport(1)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			logger, buffer := newBufferLogger()
+			observer := NewRemoteObserver(transport, "orders", NewExecutionPlan(), WithRemoteLogger(logger))
+			assert.NoError(t, observer.Listen(ctx))
+
+			assert.NoError(t, transport.Publish(ctx, "orders", []byte("{")))
+
+			assert.Eventually(t, func() bool {
+				return buffer.find("remote notification dropped") != nil
+			}, time.Second, time.Millisecond)
+		})
+	})
+}
+
+func Test_NewRemote_Panics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		transport Transport
+		topic     string
+	}{
+		{
+			name:      "[FAILURE] Should panic with a nil transport",
+			transport: nil,
+			topic:     "orders",
+		},
+
+		{
+			name:      "[FAILURE] Should panic with an empty topic",
+			transport: NewChannelTransport(0),
+			topic:     "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Panics(t, func() {
+				NewRemoteNotifier(test.transport, test.topic)
+			})
+			assert.Panics(t, func() {
+				NewRemoteObserver(test.transport, test.topic, NewExecutionPlan())
+			})
+		})
+	}
+}
@@ -2,8 +2,6 @@ package sagas
 
 import (
 	"context"
-	"math/rand"
-	"sync"
 	"time"
 )
 
@@ -21,15 +19,22 @@ type Retrier interface {
 // retrier implements the Retrier resiliency pattern, abstracting out the process of retrying a failed action
 // a certain number of times with an optional back-off between each retry.
 type retrier struct {
-	// backoff is the amount of time to wait before each retry. The length of the slice indicates how many times
-	// an action will be retried, and the value at each index indicates the amount of time waited before each.
-	backoff []time.Duration
+	// backoff computes the amount of time to wait before each retry and how many times an action will be retried.
+	backoff Backoff
+	// jitter randomizes the amount of time computed by the backoff.
+	jitter Jitter
 	// classifier is used to determine which errors should be retried and which should cause the retrier to fail fast.
 	classifier Classifier
+	// budget limits the amount of retries, it can be shared across many retriers. It is optional.
+	budget RetryBudget
+	// limiter caps the amount of attempts per second, it can be shared across many retriers. It is optional.
+	limiter RateLimiter
+	// maxRetryAfter limits the amount of time a RetryAfterError can ask to wait. It is unlimited if not positive.
+	maxRetryAfter time.Duration
 	// random is used to randomize the backoff time.
-	random *rand.Rand
-	// mutex is used to protect the random number generator.
-	mutex sync.Mutex
+	random *random
+	// clock times the attempts and the waits between them. If it is nil, the clock of the context is used.
+	clock Clock
 }
 
 // NewRetrier constructs a Retrier with the given backoff pattern and classifier. The length of the backoff pattern
@@ -43,14 +48,44 @@ type retrier struct {
 //
 // The above example creates a Retrier that will retry an action 3 times, waiting 1 second between each retry.
 // The DefaultClassifier is used to determine which errors should be retried.
+//
+// Each delay is randomized by up to 100% in both directions, unless another Jitter is given through the
+// WithRetrierJitter option.
 func NewRetrier(backoff []time.Duration, options ...RetrierOption) Retrier {
+	options = append([]RetrierOption{WithRetrierJitter(JitterProportional(1))}, options...)
+	return NewRetrierWithBackoff(BackoffSlice(backoff), options...)
+}
+
+// NewRetrierWithBackoff constructs a Retrier with the given Backoff strategy and options. The Backoff computes the
+// amount of time waited before each retry and decides when to stop retrying. Unlike NewRetrier, the delays are not
+// randomized unless a Jitter is given through the WithRetrierJitter option. Example:
+//
+//	backoff := sagas.BackoffDecorrelatedJitter(-1, 100*time.Millisecond, 10*time.Second)
+//	retrier := sagas.NewRetrierWithBackoff(backoff)
+//
+// The above example creates a Retrier that retries an action until it succeeds or its context is done, waiting
+// a decorrelated random amount of time between each retry.
+func NewRetrierWithBackoff(backoff Backoff, options ...RetrierOption) Retrier {
+	if backoff == nil {
+		panic("backoff can not be nil")
+	}
 
 	retrierOptions := newRetrierOptions(options...)
 
+	random := newTimeRandom()
+	if retrierOptions.RandomSeed != nil {
+		random = newRandom(*retrierOptions.RandomSeed)
+	}
+
 	return &retrier{
-		backoff:    backoff,
-		classifier: retrierOptions.Classifier,
-		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		backoff:       backoff,
+		jitter:        retrierOptions.Jitter,
+		classifier:    retrierOptions.Classifier,
+		budget:        retrierOptions.RetryBudget,
+		limiter:       retrierOptions.RateLimiter,
+		random:        random,
+		maxRetryAfter: retrierOptions.MaxRetryAfter,
+		clock:         retrierOptions.Clock,
 	}
 }
 
@@ -58,48 +93,126 @@ func NewRetrier(backoff []time.Duration, options ...RetrierOption) Retrier {
 // to construct the Retrier. If the result is Succeed or Fail, the return value of the work function is
 // returned to the caller. If the result is Retry, then Retry sleeps according to the its backoff policy
 // before retrying. If the total number of retries is exceeded then the return value of the work function
-// is returned to the caller regardless.
+// is returned to the caller regardless. If the classifier or the error asks to retry after a given amount of
+// time, through the RetryAfterClassifier or RetryAfterError interfaces, that amount replaces the back-off.
 func (r *retrier) Retry(ctx context.Context, action Action) error {
 	return r.retryCtx(ctx, action)
 }
 
 // retryCtx executes the given work function with context
 func (r *retrier) retryCtx(ctx context.Context, action Action) error {
+	backoff := r.backoff
+	if starter, ok := backoff.(backoffStarter); ok {
+		backoff = starter.start(r.random)
+	}
+
+	clock := r.clock
+	if clock == nil {
+		clock = clockFromContext(ctx)
+	}
+
 	retries := 0
 	for {
-		err := action.run(ctx)
-
-		switch r.classifier.Classify(err) {
-		case Successed, Failed:
-			return err
-		case retry:
-			if retries >= len(r.backoff) {
+		if r.limiter != nil {
+			if err := r.limiter.Acquire(ctx); err != nil {
 				return err
 			}
+		}
 
-			timeout := time.After(r.calcSleep(retries))
-			if err = r.sleep(ctx, timeout); err != nil {
-				return err
+		attempt := Attempt{Number: retries + 1, StartedAt: clock.Now()}
+		err := r.runAttempt(ctx, action, attempt.Number)
+		attempt.EndedAt, attempt.Err = clock.Now(), err
+
+		status := r.classifier.Classify(err)
+		attempt.Status = status
+
+		if status == Retry {
+			if sleep, ok := r.nextSleep(backoff, retries, err); ok {
+				attempt.NextDelay = sleep
+				recordAttempt(ctx, attempt, true)
+
+				if err = r.sleep(ctx, clock.NewTimer(sleep)); err != nil {
+					return err
+				}
+
+				retries++
+				continue
 			}
+		}
 
-			retries++
+		recordAttempt(ctx, attempt, false)
+
+		switch status {
+		case Successed:
+			if r.budget != nil {
+				r.budget.Deposit()
+			}
+			return err
+		case Canceled, Skipped:
+			return &statusError{status: status, err: err}
+		default:
+			return err
 		}
 	}
 }
 
-// sleep sleeps for the given duration, returning early if the context is canceled.
-func (r *retrier) sleep(ctx context.Context, t <-chan time.Time) error {
+// runAttempt runs a single attempt of the action inside a span of the tracer carried by the context.
+func (r *retrier) runAttempt(ctx context.Context, action Action, number int) error {
+	identifier, _ := stepIdentifierFromContext(ctx)
+	ctx, span := tracerFromContext(ctx).StartAttempt(withAttemptNumber(ctx, number), identifier, number)
+	err := action.run(ctx)
+	span.End(err)
+	return err
+}
+
+// nextSleep returns the amount of time to sleep before the next retry. The returned boolean is false if the
+// backoff or the retry budget do not allow another retry.
+func (r *retrier) nextSleep(backoff Backoff, retries int, err error) (time.Duration, bool) {
+	delay, ok := backoff.Next(retries, err)
+	if !ok {
+		return 0, false
+	}
+
+	if r.budget != nil && !r.budget.Withdraw() {
+		return 0, false
+	}
+
+	if after, ok := retryAfter(r.classifier, err, r.maxRetryAfter); ok {
+		return after, true
+	}
+
+	return r.calcSleep(delay), true
+}
+
+// sleep waits for the timer to fire, returning early if the context is canceled.
+func (r *retrier) sleep(ctx context.Context, timer Timer) error {
 	select {
-	case <-t:
+	case <-timer.C():
 		return nil
 	case <-ctx.Done():
+		timer.Stop()
 		return ctx.Err()
 	}
 }
 
-// calcSleep calculates the amount of time to sleep before the next retry.
-func (r *retrier) calcSleep(i int) time.Duration {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-	return r.backoff[i] + time.Duration(((r.random.Float64()*2)-1)*float64(r.backoff[i]))
+// calcSleep calculates the amount of time to sleep before the next retry, applying the jitter to the delay.
+func (r *retrier) calcSleep(delay time.Duration) time.Duration {
+	return r.jitter(delay, r.random.float64())
+}
+
+// statusError wraps the error that stopped a Retrier with the status given by its classifier, so that the Step
+// can tell a cancellation or a skip apart from a failure.
+type statusError struct {
+	status Status
+	err    error
+}
+
+// Error returns the message of the wrapped error.
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *statusError) Unwrap() error {
+	return e.err
 }
@@ -1,14 +1,28 @@
 package sagas
 
+import "time"
+
 type retrierOptions struct {
-	Classifier Classifier
+	Classifier    Classifier
+	Jitter        Jitter
+	RetryBudget   RetryBudget
+	RateLimiter   RateLimiter
+	MaxRetryAfter time.Duration
+	Clock         Clock
+	RandomSeed    *int64
 }
 
 type RetrierOption func(*retrierOptions)
 
 func newRetrierOptions(opts ...RetrierOption) retrierOptions {
 	options := retrierOptions{
-		Classifier: NewClassifier(),
+		Classifier:    NewClassifier(),
+		Jitter:        JitterNone(),
+		RetryBudget:   nil,
+		RateLimiter:   nil,
+		MaxRetryAfter: 0,
+		Clock:         nil,
+		RandomSeed:    nil,
 	}
 
 	for _, opt := range opts {
@@ -23,3 +37,49 @@ func WithRetrierClassifier(classifier Classifier) RetrierOption {
 		o.Classifier = classifier
 	}
 }
+
+// WithRetrierJitter sets the jitter used to randomize the delays computed by the backoff.
+func WithRetrierJitter(jitter Jitter) RetrierOption {
+	return func(o *retrierOptions) {
+		o.Jitter = jitter
+	}
+}
+
+// WithRetrierRetryBudget sets the retry budget shared by the retrier. Once the budget is exhausted, the
+// retrier stops retrying and returns the last error.
+func WithRetrierRetryBudget(budget RetryBudget) RetrierOption {
+	return func(o *retrierOptions) {
+		o.RetryBudget = budget
+	}
+}
+
+// WithRetrierRateLimiter sets the rate limiter acquired by the retrier before each attempt.
+func WithRetrierRateLimiter(limiter RateLimiter) RetrierOption {
+	return func(o *retrierOptions) {
+		o.RateLimiter = limiter
+	}
+}
+
+// WithRetrierMaxRetryAfter limits the amount of time a RetryAfterError or a RetryAfterClassifier can ask the
+// retrier to wait before the next retry. By default, the amount of time is not limited.
+func WithRetrierMaxRetryAfter(maximum time.Duration) RetrierOption {
+	return func(o *retrierOptions) {
+		o.MaxRetryAfter = maximum
+	}
+}
+
+// WithRetrierClock sets the clock used to time the attempts and to wait between them. By default, the clock of
+// the saga or the step is used, which is the clock of the system unless another one is given.
+func WithRetrierClock(clock Clock) RetrierOption {
+	return func(o *retrierOptions) {
+		o.Clock = clock
+	}
+}
+
+// WithRetrierRandomSeed sets the seed of the random source used by the jitter and the randomized back-offs, so
+// their delays are the same on every run. By default, the source is seeded with the current time.
+func WithRetrierRandomSeed(seed int64) RetrierOption {
+	return func(o *retrierOptions) {
+		o.RandomSeed = &seed
+	}
+}
@@ -3,6 +3,7 @@ package sagas
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -107,3 +108,231 @@ func Test_retrier_Retry(t *testing.T) {
 		})
 	}
 }
+
+func Test_retrier_Retry_WithRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		budget  RetryBudget
+		backoff []time.Duration
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantCalls int
+	}{
+		{
+			name: "[SUCCESS] Should retry while the budget has tokens",
+			args: args{
+				budget:  NewRetryBudget(0, 5),
+				backoff: BackoffConstant(3, 1*time.Millisecond),
+			},
+			wantCalls: 4,
+		},
+
+		{
+			name: "[SUCCESS] Should stop retrying when the budget is exhausted",
+			args: args{
+				budget:  NewRetryBudget(0, 1),
+				backoff: BackoffConstant(3, 1*time.Millisecond),
+			},
+			wantCalls: 2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				calls := 0
+				r := NewRetrier(test.args.backoff, WithRetrierRetryBudget(test.args.budget))
+				err := r.Retry(context.Background(), NewAction(func(context.Context) error {
+					calls++
+					return errors.New("error")
+				}))
+				assert.Equal(t, "error", err.Error())
+				assert.Equal(t, test.wantCalls, calls)
+			})
+		})
+	}
+}
+
+func Test_retrier_Retry_WithRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		limiter RateLimiter
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		wantCalls     int
+		expectedError string
+	}{
+		{
+			name: "[SUCCESS] Should attempt while the limiter allows it",
+			args: args{
+				limiter: NewRateLimiter(1, 3, RateLimitFailFast),
+			},
+			wantCalls:     3,
+			expectedError: "error",
+		},
+
+		{
+			name: "[ERROR] Should return the limiter error when the limit is exceeded",
+			args: args{
+				limiter: NewRateLimiter(1, 1, RateLimitFailFast),
+			},
+			wantCalls:     1,
+			expectedError: ErrRateLimited.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				calls := 0
+				r := NewRetrier(BackoffConstant(2, 1*time.Millisecond), WithRetrierRateLimiter(test.args.limiter))
+				err := r.Retry(context.Background(), NewAction(func(context.Context) error {
+					calls++
+					return errors.New("error")
+				}))
+				assert.Equal(t, test.expectedError, err.Error())
+				assert.Equal(t, test.wantCalls, calls)
+			})
+		})
+	}
+}
+
+func Test_retrier_RetryWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		backoff Backoff
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		wantCalls     int
+		expectedError string
+	}{
+		{
+			name: "[SUCCESS] Should retry until the backoff stops",
+			args: args{
+				backoff: BackoffFibonacci(3, 1*time.Millisecond),
+			},
+			wantCalls:     4,
+			expectedError: "error 4",
+		},
+
+		{
+			name: "[SUCCESS] Should receive the error of the last attempt in the backoff",
+			args: args{
+				backoff: BackoffFunc(func(attempt int, err error) (time.Duration, bool) {
+					return 1 * time.Millisecond, err.Error() != "error 2"
+				}),
+			},
+			wantCalls:     2,
+			expectedError: "error 2",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				calls := 0
+				r := NewRetrierWithBackoff(test.args.backoff)
+				err := r.Retry(context.Background(), NewAction(func(context.Context) error {
+					calls++
+					return fmt.Errorf("error %d", calls)
+				}))
+				assert.Equal(t, test.expectedError, err.Error())
+				assert.Equal(t, test.wantCalls, calls)
+			})
+		})
+	}
+}
+
+func Test_retrier_Retry_WithUndefinedClassification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		wantCalls int
+	}{
+		{
+			name:      "[SUCCESS] Should not retry when the classifier returns Undefined",
+			wantCalls: 1,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				calls := 0
+				r := NewRetrier(
+					BackoffConstant(3, 1*time.Millisecond),
+					WithRetrierClassifier(FirstMatch(NewClassifierRetryable())),
+				)
+				err := r.Retry(context.Background(), NewAction(func(context.Context) error {
+					calls++
+					return errors.New("error")
+				}))
+				assert.Equal(t, "error", err.Error())
+				assert.Equal(t, test.wantCalls, calls)
+			})
+		})
+	}
+}
+
+func Test_retrier_Retry_WithClock(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	s := NewStep("step", func(context.Context) error { return errors.New("error") },
+		WithStepRetrier(NewRetrier(BackoffConstant(2, time.Hour), WithRetrierClock(clock), WithRetrierJitter(JitterNone()))))
+
+	done := make(chan error)
+	go func() { done <- s.Run(context.Background()) }()
+
+	for retry := 0; retry < 2; retry++ {
+		assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+		clock.Advance(time.Hour)
+	}
+
+	assert.EqualError(t, <-done, "error")
+	attempts := s.Attempts()
+	assert.Len(t, attempts, 3)
+	for i, attempt := range attempts {
+		assert.Equal(t, start.Add(time.Duration(i)*time.Hour), attempt.StartedAt)
+	}
+}
+
+func Test_retrier_Retry_WithRandomSeed(t *testing.T) {
+	t.Parallel()
+
+	delays := func(seed int64) []time.Duration {
+		s := NewStep("step", func(context.Context) error { return errors.New("error") },
+			WithStepRetrier(NewRetrier(BackoffConstant(3, time.Millisecond), WithRetrierRandomSeed(seed))))
+		_ = s.Run(context.Background())
+
+		var delays []time.Duration
+		for _, attempt := range s.Attempts() {
+			delays = append(delays, attempt.NextDelay)
+		}
+		return delays
+	}
+
+	assert.Equal(t, delays(42), delays(42))
+	assert.NotEqual(t, delays(42), delays(7))
+}
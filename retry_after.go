@@ -0,0 +1,97 @@
+package sagas
+
+import (
+	"errors"
+	"time"
+)
+
+// RetryAfterError is the interface implemented by errors that know how long to wait before the next retry,
+// such as an error built from the Retry-After header of an HTTP response. The Retrier honours it, overriding
+// the delay computed by its Backoff.
+type RetryAfterError interface {
+	error
+	// RetryAfter returns the amount of time to wait before the next retry.
+	RetryAfter() time.Duration
+}
+
+// RetryAfterClassifier is the interface implemented by classifiers that, besides classifying an error, can tell
+// how long to wait before retrying it. The Retrier consults it before looking for a RetryAfterError.
+type RetryAfterClassifier interface {
+	Classifier
+	// RetryAfter receives an error and returns the amount of time to wait before retrying it. If the returned
+	// boolean is false, the delay computed by the Backoff is used.
+	RetryAfter(error) (time.Duration, bool)
+}
+
+// retryAfterError is the concrete implementation of the RetryAfterError interface.
+type retryAfterError struct {
+	err   error
+	delay time.Duration
+}
+
+// NewRetryAfterError wraps the given error in a RetryAfterError that asks the Retrier to wait the given delay
+// before the next retry. Example:
+//
+//	actionFn := func(ctx context.Context) error {
+//		resp, err := http.Get("http://example.com")
+//		if err != nil {
+//			return err
+//		}
+//		if resp.StatusCode == http.StatusServiceUnavailable {
+//			seconds, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+//			return sagas.NewRetryAfterError(errors.New("service unavailable"), time.Duration(seconds)*time.Second)
+//		}
+//		return nil
+//	}
+//
+// The above example creates an action that asks the Retrier to wait the amount of seconds sent by the server.
+func NewRetryAfterError(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return &retryAfterError{
+		err:   err,
+		delay: delay,
+	}
+}
+
+// Error returns the message of the wrapped error.
+func (e *retryAfterError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *retryAfterError) Unwrap() error {
+	return e.err
+}
+
+// RetryAfter returns the amount of time to wait before the next retry.
+func (e *retryAfterError) RetryAfter() time.Duration {
+	return e.delay
+}
+
+// retryAfter returns the amount of time the classifier or the error asks to wait before the next retry,
+// limited to the given maximum. A non-positive maximum does not limit the delay.
+func retryAfter(classifier Classifier, err error, maximum time.Duration) (time.Duration, bool) {
+	delay, ok := time.Duration(0), false
+
+	if c, is := classifier.(RetryAfterClassifier); is {
+		delay, ok = c.RetryAfter(err)
+	}
+
+	var retryAfterErr RetryAfterError
+	if !ok && errors.As(err, &retryAfterErr) {
+		delay, ok = retryAfterErr.RetryAfter(), true
+	}
+
+	if !ok {
+		return 0, false
+	}
+
+	if maximum > 0 {
+		delay = min(delay, maximum)
+	}
+
+	return max(delay, 0), true
+}
@@ -0,0 +1,213 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type retryAfterClassifier struct {
+	classifier
+	delay time.Duration
+}
+
+func (c retryAfterClassifier) RetryAfter(error) (time.Duration, bool) {
+	return c.delay, true
+}
+
+func Test_NewRetryAfterError(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		err   error
+		delay time.Duration
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want error
+	}{
+		{
+			name: "[SUCCESS] Should wrap the error",
+			args: args{
+				err:   assert.AnError,
+				delay: 1 * time.Second,
+			},
+			want: assert.AnError,
+		},
+
+		{
+			name: "[SUCCESS] Should return nil if the error is nil",
+			args: args{
+				err:   nil,
+				delay: 1 * time.Second,
+			},
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				got := NewRetryAfterError(test.args.err, test.args.delay)
+				if test.want == nil {
+					assert.NoError(t, got)
+					return
+				}
+				var retryAfterErr RetryAfterError
+				assert.ErrorIs(t, got, test.want)
+				assert.Equal(t, test.want.Error(), got.Error())
+				assert.True(t, errors.As(got, &retryAfterErr))
+				assert.Equal(t, test.args.delay, retryAfterErr.RetryAfter())
+			})
+		})
+	}
+}
+
+func Test_retryAfter(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		classifier Classifier
+		err        error
+		maximum    time.Duration
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		want   time.Duration
+		wantOk bool
+	}{
+		{
+			name: "[SUCCESS] Should return the delay of the error",
+			args: args{
+				classifier: NewClassifier(),
+				err:        NewRetryAfterError(assert.AnError, 2*time.Second),
+			},
+			want:   2 * time.Second,
+			wantOk: true,
+		},
+
+		{
+			name: "[SUCCESS] Should return the delay of a wrapped error",
+			args: args{
+				classifier: NewClassifier(),
+				err:        errors.Join(errors.New("other"), NewRetryAfterError(assert.AnError, 2*time.Second)),
+			},
+			want:   2 * time.Second,
+			wantOk: true,
+		},
+
+		{
+			name: "[SUCCESS] Should prefer the delay of the classifier",
+			args: args{
+				classifier: retryAfterClassifier{delay: 3 * time.Second},
+				err:        NewRetryAfterError(assert.AnError, 2*time.Second),
+			},
+			want:   3 * time.Second,
+			wantOk: true,
+		},
+
+		{
+			name: "[SUCCESS] Should limit the delay to the maximum",
+			args: args{
+				classifier: NewClassifier(),
+				err:        NewRetryAfterError(assert.AnError, 1*time.Hour),
+				maximum:    1 * time.Minute,
+			},
+			want:   1 * time.Minute,
+			wantOk: true,
+		},
+
+		{
+			name: "[SUCCESS] Should not return a delay for other errors",
+			args: args{
+				classifier: NewClassifier(),
+				err:        assert.AnError,
+			},
+			want:   0,
+			wantOk: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				got, ok := retryAfter(test.args.classifier, test.args.err, test.args.maximum)
+				assert.Equal(t, test.want, got)
+				assert.Equal(t, test.wantOk, ok)
+			})
+		})
+	}
+}
+
+func Test_retrier_Retry_WithRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		delay   time.Duration
+		maximum time.Duration
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		minElapsed time.Duration
+		maxElapsed time.Duration
+	}{
+		{
+			name: "[SUCCESS] Should wait the delay asked by the error instead of the backoff",
+			args: args{
+				delay: 50 * time.Millisecond,
+			},
+			minElapsed: 50 * time.Millisecond,
+			maxElapsed: 1 * time.Second,
+		},
+
+		{
+			name: "[SUCCESS] Should wait at most the maximum delay",
+			args: args{
+				delay:   1 * time.Hour,
+				maximum: 10 * time.Millisecond,
+			},
+			minElapsed: 10 * time.Millisecond,
+			maxElapsed: 1 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				calls := 0
+				r := NewRetrier(
+					BackoffConstant(1, 1*time.Hour),
+					WithRetrierMaxRetryAfter(test.args.maximum),
+				)
+				start := time.Now()
+				err := r.Retry(context.Background(), NewAction(func(context.Context) error {
+					calls++
+					if calls == 1 {
+						return NewRetryAfterError(assert.AnError, test.args.delay)
+					}
+					return nil
+				}))
+				elapsed := time.Since(start)
+				assert.NoError(t, err)
+				assert.Equal(t, 2, calls)
+				assert.GreaterOrEqual(t, elapsed, test.minElapsed)
+				assert.Less(t, elapsed, test.maxElapsed)
+			})
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package sagas
+
+import "sync"
+
+// RetryBudget is the interface that wraps methods to limit the amount of retries performed by one or more
+// Retriers. A single RetryBudget can be shared across many saga instances, so that during a downstream outage
+// the retries are limited to a fraction of the successful calls instead of multiplying the load.
+type RetryBudget interface {
+	// Deposit records a successful call, refilling the budget.
+	Deposit()
+	// Withdraw takes a token from the budget to perform a retry. It returns false if the budget is exhausted
+	// and the retry should not be performed.
+	Withdraw() bool
+}
+
+// retryBudget is a token bucket implementation of the RetryBudget interface.
+type retryBudget struct {
+	// ratio is the amount of tokens deposited for each successful call.
+	ratio float64
+	// capacity is the maximum amount of tokens the bucket can hold.
+	capacity float64
+	// tokens is the current amount of tokens in the bucket.
+	tokens float64
+	// mutex is used to protect the tokens.
+	mutex sync.Mutex
+}
+
+// NewRetryBudget constructs a RetryBudget backed by a token bucket. The bucket starts full with capacity tokens,
+// each retry withdraws one token and each successful call deposits ratio tokens, so in the long run the retries
+// are limited to ratio times the successful calls. A panic will occur if the ratio is negative or the capacity
+// is not positive. Example:
+//
+//	budget := sagas.NewRetryBudget(0.1, 10)
+//
+//	retrier := sagas.NewRetrier(sagas.BackoffConstant(3, 1*time.Second), sagas.WithRetrierRetryBudget(budget))
+//
+// The above example creates a RetryBudget that allows a burst of 10 retries and, thereafter, one retry for each
+// ten successful calls of every Retrier sharing it.
+func NewRetryBudget(ratio float64, capacity int) RetryBudget {
+	if ratio < 0 {
+		panic("ratio can not be negative")
+	}
+
+	if capacity <= 0 {
+		panic("capacity must be positive")
+	}
+
+	return &retryBudget{
+		ratio:    ratio,
+		capacity: float64(capacity),
+		tokens:   float64(capacity),
+	}
+}
+
+// Deposit records a successful call, adding ratio tokens to the bucket up to its capacity.
+func (b *retryBudget) Deposit() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.tokens = min(b.capacity, b.tokens+b.ratio)
+}
+
+// Withdraw takes a token from the bucket. It returns false if there is not a whole token left.
+func (b *retryBudget) Withdraw() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,144 @@
+package sagas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ratio    float64
+		capacity int
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		shouldPanic bool
+	}{
+		{
+			name: "[SUCCESS] Should return a new RetryBudget",
+			args: args{
+				ratio:    0.1,
+				capacity: 10,
+			},
+		},
+
+		{
+			name: "[PANIC] Should panic if the ratio is negative",
+			args: args{
+				ratio:    -1,
+				capacity: 10,
+			},
+			shouldPanic: true,
+		},
+
+		{
+			name: "[PANIC] Should panic if the capacity is not positive",
+			args: args{
+				ratio:    0.1,
+				capacity: 0,
+			},
+			shouldPanic: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if test.shouldPanic {
+				assert.Panics(t, func() {
+					NewRetryBudget(test.args.ratio, test.args.capacity)
+				})
+				return
+			}
+			assert.NotPanics(t, func() {
+				got := NewRetryBudget(test.args.ratio, test.args.capacity)
+				assert.NotNil(t, got)
+			})
+		})
+	}
+}
+
+func Test_retryBudget_Withdraw(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		capacity int
+		ratio    float64
+		spent    int
+		deposits int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "[SUCCESS] Should allow withdraws up to the capacity",
+			args: args{
+				capacity: 2,
+				ratio:    0.5,
+			},
+			want: 2,
+		},
+
+		{
+			name: "[SUCCESS] Should refuse withdraws when the budget is exhausted",
+			args: args{
+				capacity: 2,
+				ratio:    0.5,
+				spent:    2,
+			},
+			want: 0,
+		},
+
+		{
+			name: "[SUCCESS] Should allow withdraws after enough deposits",
+			args: args{
+				capacity: 2,
+				ratio:    0.5,
+				spent:    2,
+				deposits: 3,
+			},
+			want: 1,
+		},
+
+		{
+			name: "[SUCCESS] Should not refill the budget over its capacity",
+			args: args{
+				capacity: 2,
+				ratio:    0.5,
+				spent:    2,
+				deposits: 10,
+			},
+			want: 2,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				b := NewRetryBudget(test.args.ratio, test.args.capacity)
+				for i := 0; i < test.args.spent; i++ {
+					b.Withdraw()
+				}
+				for i := 0; i < test.args.deposits; i++ {
+					b.Deposit()
+				}
+				got := 0
+				for b.Withdraw() {
+					got++
+				}
+				assert.Equal(t, test.want, got)
+			})
+		})
+	}
+}
@@ -2,6 +2,9 @@ package sagas
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 )
 
 // EndFn is a function that returns a boolean value. It is used to indicate
@@ -12,6 +15,8 @@ type EnderFn func() bool
 // orchestrating the steps and the notifications. It is also responsible for
 // running the saga. It is the main interface of the package.
 type Saga interface {
+	// GetIdentifier returns the identifier of the saga instance.
+	GetIdentifier() Identifier
 	// AddSteps adds the steps to the Saga. It must receive at least a starter
 	// step and a middle step. It can receive more than one middle step.
 	AddSteps(starterStep Step, steps ...Step)
@@ -26,12 +31,20 @@ type Saga interface {
 	// occurs.
 	Then(actions ...Action) Saga
 	// Plan returns a Saga. It is used to indicate that the Saga is ready to
-	// run. It must be called after the When, Is and Then methods.
-	Plan()
+	// run. It must be called after the When, Is and Then methods. The options
+	// apply to the actions of this transition.
+	Plan(options ...TransitionOption)
 	// Run runs the Saga. It receives a context and an enderFn as parameters.
 	// The context is used to cancel the execution of the saga. The enderFn is
 	// used to indicate when the saga should end.
 	Run(ctx context.Context, enderFn EnderFn)
+	// Resume runs the parked steps of the saga instance, as recorded in its
+	// SagaStore, instead of its starter step. It is used to continue a saga
+	// that was parked by another process, rebuilt with the same identifiers.
+	Resume(ctx context.Context, enderFn EnderFn) error
+	// Signal sends a signal with a payload to the wait steps of the saga
+	// instance. A signal sent before a step waits for it is kept until then.
+	Signal(ctx context.Context, name string, payload []byte) error
 }
 
 // steps is a struct that represents the steps of the saga. It is composed by
@@ -64,13 +77,27 @@ func newPlanner() *planner {
 }
 
 // saga is the concrete implementation of the Saga interface. It is composed
-// by an execution plan, an observer, a notifier and a planner.
+// by an identifier, an execution plan, an observer, a notifier, a planner, a
+// tracer, a metrics recorder and a logger.
 type saga struct {
-	Expl     ExecutionPlan
-	Notifier Notifier
-	Observer Observer
-	Planner  *planner
-	Steps    *steps
+	Identifier       Identifier
+	Expl             ExecutionPlan
+	Notifier         Notifier
+	Observer         Observer
+	Observers        []Observer
+	Middlewares      []ActionMiddleware
+	Hooks            []Hooks
+	Planner          *planner
+	Steps            *steps
+	Tracer           Tracer
+	Metrics          MetricsRecorder
+	Logger           *slog.Logger
+	IdempotencyStore IdempotencyStore
+	Store            SagaStore
+	Scheduler        Scheduler
+	Clock            Clock
+	WorkerPool       WorkerPool
+	signals          *signalHub
 }
 
 // NewSaga returns a new concrete implementation of the Saga interface.
@@ -78,14 +105,47 @@ func NewSaga(options ...SagaOption) Saga {
 
 	sagaOption := newSagasOptions(options...)
 
+	identifier := sagaOption.Identifier
+	if identifier == nil {
+		identifier = NewIdentifier(sagaOption.Name)
+	}
+
+	scheduler := sagaOption.Scheduler
+	if scheduler == nil {
+		scheduler = NewScheduler(sagaOption.Clock)
+	}
+
+	pool := sagaOption.WorkerPool
+	if pool == nil && sagaOption.MaxConcurrency > 0 {
+		pool = NewWorkerPool(sagaOption.MaxConcurrency)
+	}
+
 	return &saga{
-		Expl:     sagaOption.ExecutionPlan,
-		Notifier: sagaOption.Notifier,
-		Planner:  newPlanner(),
-		Steps:    newSteps(),
+		Identifier:       identifier,
+		Expl:             sagaOption.ExecutionPlan,
+		Notifier:         sagaOption.Notifier,
+		Planner:          newPlanner(),
+		Steps:            newSteps(),
+		Tracer:           sagaOption.Tracer,
+		Metrics:          sagaOption.Metrics,
+		Logger:           sagaOption.Logger,
+		IdempotencyStore: sagaOption.IdempotencyStore,
+		Store:            sagaOption.Store,
+		Scheduler:        scheduler,
+		Clock:            sagaOption.Clock,
+		Observers:        sagaOption.Observers,
+		Middlewares:      sagaOption.Middlewares,
+		Hooks:            sagaOption.Hooks,
+		WorkerPool:       pool,
+		signals:          newSignalHub(),
 	}
 }
 
+// GetIdentifier returns the identifier of the saga instance.
+func (c *saga) GetIdentifier() Identifier {
+	return c.Identifier
+}
+
 // AddSteps adds the steps to the Saga. It must receive at least a starter
 // step and a middle step. It can receive more than one middle step. Example:
 //
@@ -145,42 +205,197 @@ func (c *saga) Then(actions ...Action) Saga {
 }
 
 // Plan returns a Saga. It is used to indicate that the Saga is ready to
-// run. It must be called after the When, Is and Then methods.
-func (c *saga) Plan() {
+// run. It must be called after the When, Is and Then methods. The options
+// apply to the actions of this transition. Example:
+//
+//	saga.When(starter).Is(sagas.Completed).Then(shipments...).Plan(sagas.WithTransitionMaxConcurrency(4))
+//
+// The above example will run at most 4 of the shipments at once.
+func (c *saga) Plan(options ...TransitionOption) {
+	actions := c.Planner.actions
+	if len(options) > 0 {
+		actions = []Action{newTransition(actions, options...)}
+	}
 	c.Expl.Add(Notification{
 		Identifier: c.Planner.identifier,
 		Event:      c.Planner.event,
-	}, c.Planner.actions...)
+	}, actions...)
 	c.Planner = newPlanner()
 }
 
 // Run runs the Saga. It receives a context and an enderFn as parameters.
 // The context is used to cancel the execution of the saga. The enderFn is
-// used to indicate when the saga should end.
+// used to indicate when the saga should end. The execution is traced by the
+// tracer of the saga, whose span is the parent of the spans of the steps, and
+// measured by its metrics recorder. The logger of the saga is given to the steps.
+// The saga also ends when the context is done, leaving its waiting steps parked.
 func (c *saga) Run(ctx context.Context, enderFn EnderFn) {
+	c.run(ctx, enderFn, func(ctx context.Context) {
+		c.Steps.starter.Run(ctx)
+	})
+}
+
+// Resume runs the parked steps of the saga instance, as recorded in its SagaStore,
+// instead of its starter step. The saga must be rebuilt with the identifier of the
+// parked instance and steps with stable identifiers. It returns an error if the
+// parked steps could not be loaded or are not steps of the saga. Example:
+//
+//	saga := sagas.NewSaga(sagas.WithSagaIdentifier(id), sagas.WithSagaStore(store))
+//
+//	saga.AddSteps(starterStep, approvalStep, shipStep)
+//
+//	err := saga.Resume(ctx, func() bool { return shipStep.GetState() == sagas.Completed })
+//
+// The above example will resume the saga, waiting again for the approval.
+func (c *saga) Resume(ctx context.Context, enderFn EnderFn) error {
+	parked, err := c.Store.Parked(ctx, c.Identifier)
+	if err != nil {
+		return err
+	}
+
+	steps := make(map[string]Step)
+	for _, step := range append([]Step{c.Steps.starter}, c.Steps.middles...) {
+		steps[step.GetIdentifier().String()] = step
+	}
+
+	resumed := make([]Step, 0, len(parked))
+	for _, p := range parked {
+		step, ok := steps[p.Step.String()]
+		if !ok {
+			return fmt.Errorf("parked step %s is not a step of the saga", p.Step)
+		}
+		resumed = append(resumed, step)
+	}
+
+	c.run(ctx, enderFn, func(ctx context.Context) {
+		for _, step := range resumed {
+			go step.Run(ctx)
+		}
+	})
+	return nil
+}
+
+// Signal sends a signal with a payload to the wait steps of the saga instance. The
+// signal is kept in the SagaStore until a step waiting for it takes it, so a signal
+// sent before the step waits, or while the saga is not running, is not lost. It
+// returns an error if the name is empty or the signal could not be stored. Example:
+//
+//	err := saga.Signal(ctx, "approved", []byte(`{"by":"alice"}`))
+//
+// The above example will wake the step waiting for the approval.
+func (c *saga) Signal(ctx context.Context, name string, payload []byte) error {
+	if name == "" {
+		return errors.New("signal name cannot be empty")
+	}
+
+	if err := c.Store.SaveSignal(ctx, c.Identifier, name, payload); err != nil {
+		return err
+	}
+	c.signals.notify(name)
+	return nil
+}
+
+// run runs the saga, starting it with the given function, until the enderFn
+// returns true or the context is done.
+func (c *saga) run(ctx context.Context, enderFn EnderFn, start func(context.Context)) {
+	if parent, ok := sagaIdentifierFromContext(ctx); ok {
+		ctx = withParentSagaIdentifier(ctx, parent)
+	}
+	ctx = withSagaIdentifier(withMetrics(withTracer(ctx, c.Tracer), c.Metrics), c.Identifier)
+	ctx = withParking(withLogger(ctx, c.Logger), parking{store: c.Store, hub: c.signals})
+	ctx = withClock(withScheduler(ctx, c.Scheduler), c.Clock)
+	ctx = withWorkerPool(ctx, c.WorkerPool)
+	if len(c.Middlewares) > 0 {
+		ctx = withMiddlewares(ctx, c.Middlewares)
+	}
+	hooks := hookList(c.Hooks)
+	ctx = withHooks(ctx, hooks)
+	if c.IdempotencyStore != nil {
+		ctx = withIdempotencyStore(ctx, c.IdempotencyStore)
+	}
+	ctx, span := c.Tracer.StartSaga(ctx, c.Identifier)
+	defer span.End(nil)
+
+	startedAt := c.Clock.Now()
+	c.Metrics.SagaStarted(c.Identifier)
+	logContext(ctx, slog.LevelDebug, "saga started")
+	hooks.start(ctx, SagaInfo{Saga: c.Identifier, StartedAt: startedAt})
+
+	ended := false
+	defer func() {
+		duration := c.Clock.Now().Sub(startedAt)
+		c.Metrics.SagaCompleted(c.Identifier, duration)
+		logContext(ctx, slog.LevelDebug, "saga completed", slog.Duration("duration", duration))
+
+		info := SagaInfo{Saga: c.Identifier, StartedAt: startedAt, Duration: duration}
+		if !ended {
+			info.Err = ctx.Err()
+		}
+		hooks.finish(ctx, info)
+	}()
+
 	c.Observer = NewObserver(c.Expl)
 	c.centralizeNorifiers()
-	c.Steps.starter.Run(ctx)
+	start(ctx)
 	for {
-		if enderFn() {
+		if ended = enderFn(); ended || ctx.Err() != nil {
 			break
 		}
 	}
 }
 
 func (c *saga) centralizeNorifiers() {
-	c.Steps.starter.getNotifier().Add(c.Observer)
+	observer := c.Observer
+	if len(c.Observers) > 0 {
+		observer = &observerChain{observers: append(append([]Observer(nil), c.Observers...), c.Observer)}
+	}
+
+	c.Steps.starter.getNotifier().Add(observer)
 	for _, step := range c.Steps.middles {
-		step.getNotifier().Add(c.Observer)
+		step.getNotifier().Add(observer)
 	}
 }
 
 func (c *saga) spreadAllEvents(step Step) {
 	for _, event := range callableEventList {
+		event := event
 		c.When(step).Is(event).Then(NewAction(func(ctx context.Context) error {
-			n, _ := NewNotification(step.GetIdentifier(), event)
+			n, ok := notificationFromContext(ctx)
+			if !ok {
+				n, _ = NewNotification(step.GetIdentifier(), event)
+			}
 			c.Notifier.Notify(ctx, n)
 			return nil
 		})).Plan()
 	}
 }
+
+// sagaIdentifierKey is the context key of the identifier of the running saga.
+type sagaIdentifierKey struct{}
+
+// withSagaIdentifier returns a copy of the context that carries the identifier of the running saga.
+func withSagaIdentifier(ctx context.Context, identifier Identifier) context.Context {
+	return context.WithValue(ctx, sagaIdentifierKey{}, identifier)
+}
+
+// sagaIdentifierFromContext returns the identifier of the running saga carried by the context, if any.
+func sagaIdentifierFromContext(ctx context.Context) (Identifier, bool) {
+	identifier, ok := ctx.Value(sagaIdentifierKey{}).(Identifier)
+	return identifier, ok
+}
+
+// parentSagaIdentifierKey is the context key of the identifier of the parent of the running saga.
+type parentSagaIdentifierKey struct{}
+
+// withParentSagaIdentifier returns a copy of the context that carries the identifier of the parent of the
+// running saga.
+func withParentSagaIdentifier(ctx context.Context, identifier Identifier) context.Context {
+	return context.WithValue(ctx, parentSagaIdentifierKey{}, identifier)
+}
+
+// parentSagaIdentifierFromContext returns the identifier of the parent of the running saga carried by the
+// context, if any.
+func parentSagaIdentifierFromContext(ctx context.Context) (Identifier, bool) {
+	identifier, ok := ctx.Value(parentSagaIdentifierKey{}).(Identifier)
+	return identifier, ok
+}
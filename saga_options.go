@@ -1,16 +1,46 @@
 package sagas
 
+import "log/slog"
+
 type sagaOptions struct {
-	ExecutionPlan ExecutionPlan
-	Notifier      Notifier
+	Name             string
+	ExecutionPlan    ExecutionPlan
+	Notifier         Notifier
+	Tracer           Tracer
+	Metrics          MetricsRecorder
+	Logger           *slog.Logger
+	Identifier       Identifier
+	IdempotencyStore IdempotencyStore
+	Store            SagaStore
+	Scheduler        Scheduler
+	Clock            Clock
+	Observers        []Observer
+	Middlewares      []ActionMiddleware
+	Hooks            []Hooks
+	MaxConcurrency   int
+	WorkerPool       WorkerPool
 }
 
 type SagaOption func(*sagaOptions)
 
 func newSagasOptions(opts ...SagaOption) *sagaOptions {
 	opt := &sagaOptions{
-		ExecutionPlan: NewExecutionPlan(),
-		Notifier:      NewNotifier(),
+		Name:             "saga",
+		ExecutionPlan:    NewExecutionPlan(),
+		Notifier:         NewNotifier(),
+		Tracer:           noopTracer{},
+		Metrics:          noopMetrics{},
+		Logger:           discardLogger,
+		Identifier:       nil,
+		IdempotencyStore: nil,
+		Store:            NewMemorySagaStore(),
+		Scheduler:        nil,
+		Clock:            NewSystemClock(),
+		Observers:        nil,
+		Middlewares:      nil,
+		Hooks:            nil,
+		MaxConcurrency:   0,
+		WorkerPool:       nil,
 	}
 
 	for _, o := range opts {
@@ -20,6 +50,13 @@ func newSagasOptions(opts ...SagaOption) *sagaOptions {
 	return opt
 }
 
+// WithSagaName sets the name of the saga, used to create its identifier. By default, the name is "saga".
+func WithSagaName(name string) SagaOption {
+	return func(o *sagaOptions) {
+		o.Name = name
+	}
+}
+
 // WithSagaExecutionPlan sets the execution plan to the saga.
 func WithSagaExecutionPlan(plan ExecutionPlan) SagaOption {
 	return func(o *sagaOptions) {
@@ -33,3 +70,111 @@ func WithSagaNotifier(notifier Notifier) SagaOption {
 		o.Notifier = notifier
 	}
 }
+
+// WithSagaTracer sets the tracer to the saga. It is used by the steps that do not have a tracer of their own.
+func WithSagaTracer(tracer Tracer) SagaOption {
+	return func(o *sagaOptions) {
+		o.Tracer = tracer
+	}
+}
+
+// WithSagaMetrics sets the metrics recorder to the saga. It is shared by every step of the saga.
+func WithSagaMetrics(metrics MetricsRecorder) SagaOption {
+	return func(o *sagaOptions) {
+		o.Metrics = metrics
+	}
+}
+
+// WithSagaLogger sets the logger to the saga. Every log line of the engine carries the identifiers of the saga
+// instance and the step, the event and the attempt as attributes. By default, nothing is logged.
+func WithSagaLogger(logger *slog.Logger) SagaOption {
+	return func(o *sagaOptions) {
+		o.Logger = logger
+	}
+}
+
+// WithSagaIdentifier sets the identifier of the saga instance, instead of a random unique one made from its name,
+// such as a stable one made with NewStableIdentifier or a deterministic one made with NewIdentifierWithClock. A
+// saga that is resumed after a crash must keep its identifier, so that the idempotency keys of its steps do not
+// change. Its steps must keep theirs too, with WithStepIdentifier and NewStableIdentifier.
+func WithSagaIdentifier(identifier Identifier) SagaOption {
+	return func(o *sagaOptions) {
+		o.Identifier = identifier
+	}
+}
+
+// WithSagaIdempotencyStore sets the store that keeps the results of the steps of the saga. The steps recorded
+// as succeeded are not run again and their stored outputs are restored instead. The records are found by the
+// identifiers of the saga and of the steps, so only the steps with stable identifiers are found again by a saga
+// rebuilt in another process.
+func WithSagaIdempotencyStore(store IdempotencyStore) SagaOption {
+	return func(o *sagaOptions) {
+		o.IdempotencyStore = store
+	}
+}
+
+// WithSagaStore sets the store of the parked state of the saga, which keeps its waiting steps and the signals
+// they have not taken yet. By default, the parked state is kept in memory.
+func WithSagaStore(store SagaStore) SagaOption {
+	return func(o *sagaOptions) {
+		o.Store = store
+	}
+}
+
+// WithSagaScheduler sets the scheduler of the delayed steps of the saga. By default, the steps are scheduled at
+// the time of the clock of the saga.
+func WithSagaScheduler(scheduler Scheduler) SagaOption {
+	return func(o *sagaOptions) {
+		o.Scheduler = scheduler
+	}
+}
+
+// WithSagaClock sets the clock of the saga, used to time it and given to its steps, their retriers and their
+// actions. By default, it is the clock of the system.
+func WithSagaClock(clock Clock) SagaOption {
+	return func(o *sagaOptions) {
+		o.Clock = clock
+	}
+}
+
+// WithSagaObserver adds an observer of the notifications of the steps of the saga. The observers added run, in
+// the order they were added, before the saga acts on each notification, so they see the notifications in the
+// order the steps emit them.
+func WithSagaObserver(observer Observer) SagaOption {
+	return func(o *sagaOptions) {
+		o.Observers = append(o.Observers, observer)
+	}
+}
+
+// WithSagaMiddleware adds middlewares that wrap every attempt of every step of the saga, including the retries
+// and the compensations, outside the middlewares of the steps. The first middleware is the outermost.
+func WithSagaMiddleware(middlewares ...ActionMiddleware) SagaOption {
+	return func(o *sagaOptions) {
+		o.Middlewares = append(o.Middlewares, middlewares...)
+	}
+}
+
+// WithSagaHooks adds lifecycle hooks to the saga. The hooks are called synchronously, in the order they were
+// added, as the saga and its steps start and end.
+func WithSagaHooks(hooks Hooks) SagaOption {
+	return func(o *sagaOptions) {
+		o.Hooks = append(o.Hooks, hooks)
+	}
+}
+
+// WithSagaMaxConcurrency limits the number of actions of the saga that run at once, with a worker pool of the
+// given size. It is ignored if a worker pool is set with WithSagaWorkerPool. By default, the actions are not
+// limited.
+func WithSagaMaxConcurrency(maximum int) SagaOption {
+	return func(o *sagaOptions) {
+		o.MaxConcurrency = maximum
+	}
+}
+
+// WithSagaWorkerPool sets the worker pool that runs the actions of the saga. A worker pool can be shared by
+// many sagas to limit the actions that run at once across all of them.
+func WithSagaWorkerPool(pool WorkerPool) SagaOption {
+	return func(o *sagaOptions) {
+		o.WorkerPool = pool
+	}
+}
@@ -0,0 +1,35 @@
+package sagas
+
+import (
+	"context"
+	"time"
+)
+
+// ParkedStep is a step of a saga instance that is waiting for a signal or until a deadline. It is persisted in
+// a SagaStore, so the saga can be resumed by another process.
+type ParkedStep struct {
+	// Step is the identifier of the parked step. It must be stable for the saga to be resumed.
+	Step Identifier
+	// Signal is the name of the signal the step is waiting for. It is empty for steps that only wait for the
+	// deadline.
+	Signal string
+	// Deadline is the time the step stops waiting. It is zero if the step waits indefinitely.
+	Deadline time.Time
+}
+
+// SagaStore is the interface that wraps the methods to persist the parked state of the saga instances: their
+// parked steps and the signals they have not consumed yet. A saga with a SagaStore backed by a database can be
+// resumed days later by another process with Saga.Resume.
+type SagaStore interface {
+	// Park records that the step of the saga is parked, replacing any previous record of the step.
+	Park(ctx context.Context, saga Identifier, parked ParkedStep) error
+	// Unpark removes the record of the parked step of the saga.
+	Unpark(ctx context.Context, saga Identifier, step Identifier) error
+	// Parked returns the parked steps of the saga.
+	Parked(ctx context.Context, saga Identifier) ([]ParkedStep, error)
+	// SaveSignal records a signal sent to the saga, after the signals of the same name not yet taken.
+	SaveSignal(ctx context.Context, saga Identifier, name string, payload []byte) error
+	// TakeSignal removes and returns the oldest signal of the name sent to the saga. The returned boolean is
+	// false if there is none.
+	TakeSignal(ctx context.Context, saga Identifier, name string) ([]byte, bool, error)
+}
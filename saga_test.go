@@ -3,7 +3,10 @@ package sagas
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -175,3 +178,160 @@ func Test_saga_Run(t *testing.T) {
 		})
 	}
 }
+
+func Test_saga_Run_WithObserver(t *testing.T) {
+	t.Parallel()
+	var mutex sync.Mutex
+	var events []string
+	observer := NewObserverFunc(func(_ context.Context, n Notification) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, IdentifierName(n.Identifier)+":"+n.Event.String())
+	})
+
+	starter := NewStep("starter", func(context.Context) error { return nil })
+	middle := NewStep("middle", func(context.Context) error { return nil })
+	c := NewSaga(WithSagaObserver(observer))
+	c.AddSteps(starter, middle)
+	c.When(starter).Is(Successed).Then(NewAction(middle.Run)).Plan()
+	c.Run(context.Background(), func() bool { return middle.GetState() == Completed })
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, []string{
+		"starter:Running", "starter:Successed", "middle:Running", "middle:Successed", "middle:Completed",
+	}, without(events, "starter:Completed"))
+}
+
+func Test_saga_Run_MaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		options func(pool WorkerPool) []SagaOption
+		plan    []TransitionOption
+		maximum int32
+	}{
+		{
+			name:    "[SUCCESS] Should run at most the maximum concurrency of actions of the saga at once",
+			options: func(WorkerPool) []SagaOption { return []SagaOption{WithSagaMaxConcurrency(2)} },
+			maximum: 2,
+		},
+		{
+			name:    "[SUCCESS] Should run at most the maximum concurrency of actions of the transition at once",
+			options: func(WorkerPool) []SagaOption { return nil },
+			plan:    []TransitionOption{WithTransitionMaxConcurrency(3)},
+			maximum: 3,
+		},
+		{
+			name:    "[SUCCESS] Should run the actions of the saga in the shared worker pool",
+			options: func(pool WorkerPool) []SagaOption { return []SagaOption{WithSagaWorkerPool(pool)} },
+			maximum: 1,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var running, peak, done int32
+			action := func(context.Context) error {
+				current := atomic.AddInt32(&running, 1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				atomic.AddInt32(&done, 1)
+				return nil
+			}
+
+			starter := NewStep("starter", func(context.Context) error { return nil })
+			actions := make([]Action, 8)
+			for i := range actions {
+				actions[i] = NewAction(action)
+			}
+
+			c := NewSaga(test.options(NewWorkerPool(1))...)
+			c.AddSteps(starter)
+			c.When(starter).Is(Successed).Then(actions...).Plan(test.plan...)
+			c.Run(context.Background(), func() bool { return atomic.LoadInt32(&done) == int32(len(actions)) })
+
+			assert.LessOrEqual(t, atomic.LoadInt32(&peak), test.maximum)
+		})
+	}
+}
+
+func Test_saga_Run_Sequential(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		plan func(c Saga, starter Step, action func(name string) Action)
+		want []string
+	}{
+		{
+			name: "[SUCCESS] Should run the sequential transitions of a notification in the order they were planned",
+			plan: func(c Saga, starter Step, action func(string) Action) {
+				c.When(starter).Is(Successed).Then(action("a"), action("b")).Plan(WithTransitionSequential())
+				c.When(starter).Is(Successed).Then(action("c")).Plan(WithTransitionSequential())
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "[SUCCESS] Should run the sequential transitions of a notification by priority",
+			plan: func(c Saga, starter Step, action func(string) Action) {
+				c.When(starter).Is(Successed).Then(action("a")).Plan(WithTransitionPriority(1))
+				c.When(starter).Is(Successed).Then(action("b")).Plan(WithTransitionPriority(2))
+				c.When(starter).Is(Successed).Then(action("c")).Plan(WithTransitionPriority(3))
+			},
+			want: []string{"c", "b", "a"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var mutex sync.Mutex
+			var ran []string
+			action := func(name string) Action {
+				return NewAction(func(context.Context) error {
+					mutex.Lock()
+					defer mutex.Unlock()
+					ran = append(ran, name)
+					return nil
+				})
+			}
+			done := func() bool {
+				mutex.Lock()
+				defer mutex.Unlock()
+				return len(ran) == 3
+			}
+
+			starter := NewStep("starter", func(context.Context) error { return nil })
+			c := NewSaga()
+			c.AddSteps(starter)
+			test.plan(c, starter, action)
+			c.Run(context.Background(), done)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			assert.Equal(t, test.want, ran)
+		})
+	}
+}
+
+// without returns the events except the given one, whose position depends on the scheduling of the goroutines.
+func without(events []string, event string) []string {
+	result := make([]string, 0, len(events))
+	for _, e := range events {
+		if e != event {
+			result = append(result, e)
+		}
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package sagastest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertPath asserts that the recorder recorded the given entries, in the "name:Event" format, in the given
+// order. Other notifications may occur between them. Example:
+//
+//	sagastest.AssertPath(t, recorder, "reserve:Successed", "charge:Failed", "release:Completed")
+//
+// The above example will assert that the reservation succeeded, then the charge failed and the reservation was
+// released.
+func AssertPath(t testing.TB, recorder Recorder, path ...string) bool {
+	t.Helper()
+
+	recorded := recorder.Path()
+	next := 0
+	for _, entry := range recorded {
+		if next < len(path) && entry == path[next] {
+			next++
+		}
+	}
+
+	if next == len(path) {
+		return true
+	}
+	return assert.Fail(t, "saga did not take the expected path",
+		"missing %q from the expected path\nexpected: %s\nrecorded: %s",
+		path[next], strings.Join(path, " -> "), strings.Join(recorded, " -> "))
+}
+
+// AssertCompensated asserts that the given step failed and that, after it failed, every given compensation step
+// succeeded. Example:
+//
+//	sagastest.AssertCompensated(t, recorder, "charge", "release", "refund")
+//
+// The above example will assert that the charge failed and that the reservation was released and the payment
+// refunded.
+func AssertCompensated(t testing.TB, recorder Recorder, failed string, compensations ...string) bool {
+	t.Helper()
+
+	recorded := recorder.Path()
+	failedAt := -1
+	for i, entry := range recorded {
+		if entry == failed+":Failed" {
+			failedAt = i
+			break
+		}
+	}
+
+	if failedAt < 0 {
+		return assert.Fail(t, "step did not fail", "step %q did not fail\nrecorded: %s",
+			failed, strings.Join(recorded, " -> "))
+	}
+
+	ok := true
+	for _, compensation := range compensations {
+		found := false
+		for _, entry := range recorded[failedAt+1:] {
+			if entry == compensation+":Successed" {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			ok = assert.Fail(t, "step was not compensated",
+				"compensation %q did not succeed after %q failed\nrecorded: %s",
+				compensation, failed, strings.Join(recorded, " -> "))
+		}
+	}
+	return ok
+}
@@ -0,0 +1,126 @@
+package sagastest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rilder-almeida/sagas"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeT is a testing.TB that records whether an assertion failed.
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+// Errorf records the failure.
+func (t *fakeT) Errorf(string, ...interface{}) {
+	t.failed = true
+}
+
+// Helper does nothing.
+func (t *fakeT) Helper() {}
+
+// runCompensatedSaga runs a saga whose charge fails and whose reservation is released.
+func runCompensatedSaga() Recorder {
+	recorder := NewRecorder()
+	reserve := NewStep("reserve", Succeed())
+	charge := NewStep("charge", Fail(errors.New("declined")))
+	release := NewStep("release", Succeed(), sagas.WithStepCompensation())
+
+	saga := sagas.NewSaga(sagas.WithSagaObserver(recorder))
+	saga.AddSteps(reserve, charge, release)
+	saga.When(reserve).Is(sagas.Successed).Then(sagas.NewAction(charge.Run)).Plan()
+	saga.When(charge).Is(sagas.Failed).Then(sagas.NewAction(release.Run)).Plan()
+	saga.Run(context.Background(), func() bool { return release.GetState() == sagas.Completed })
+	return recorder
+}
+
+func Test_AssertPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		path     []string
+		wantFail bool
+	}{
+		{
+			name: "[SUCCESS] Should pass when the saga took the path",
+			path: []string{"reserve:Successed", "charge:Failed", "release:Completed"},
+		},
+
+		{
+			name:     "[FAILURE] Should fail when the entries are out of order",
+			path:     []string{"charge:Failed", "reserve:Successed"},
+			wantFail: true,
+		},
+
+		{
+			name:     "[FAILURE] Should fail when an entry is missing",
+			path:     []string{"reserve:Successed", "charge:Successed"},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			recorder := runCompensatedSaga()
+			fake := &fakeT{TB: t}
+
+			ok := AssertPath(fake, recorder, test.path...)
+
+			assert.Equal(t, !test.wantFail, ok, fmt.Sprint(recorder.Path()))
+			assert.Equal(t, test.wantFail, fake.failed)
+		})
+	}
+}
+
+func Test_AssertCompensated(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		failed        string
+		compensations []string
+		wantFail      bool
+	}{
+		{
+			name:          "[SUCCESS] Should pass when the compensations succeeded after the failure",
+			failed:        "charge",
+			compensations: []string{"release"},
+		},
+
+		{
+			name:          "[FAILURE] Should fail when the step did not fail",
+			failed:        "reserve",
+			compensations: []string{"release"},
+			wantFail:      true,
+		},
+
+		{
+			name:          "[FAILURE] Should fail when a compensation did not succeed",
+			failed:        "charge",
+			compensations: []string{"release", "refund"},
+			wantFail:      true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			recorder := runCompensatedSaga()
+			fake := &fakeT{TB: t}
+
+			ok := AssertCompensated(fake, recorder, test.failed, test.compensations...)
+
+			assert.Equal(t, !test.wantFail, ok)
+			assert.Equal(t, test.wantFail, fake.failed)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package sagastest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rilder-almeida/sagas"
+)
+
+// Recorder is a sagas.Observer that records the notifications of the steps of a saga, in the order they occur.
+type Recorder interface {
+	sagas.Observer
+	// Notifications returns the recorded notifications.
+	Notifications() []sagas.Notification
+	// Path returns the recorded notifications in the "name:Event" format, where name is the name of the step.
+	Path() []string
+}
+
+// recorder is the concrete implementation of the Recorder interface.
+type recorder struct {
+	sagas.Observer
+	notifications []sagas.Notification
+	mutex         sync.Mutex
+}
+
+// NewRecorder returns a new Recorder with no notifications. Example:
+//
+//	recorder := sagastest.NewRecorder()
+//
+//	saga := sagas.NewSaga(sagas.WithSagaObserver(recorder))
+//
+// The above example will record the notifications of the steps of the saga.
+func NewRecorder() Recorder {
+	r := &recorder{}
+	r.Observer = sagas.NewObserverFunc(r.record)
+	return r
+}
+
+// Notifications returns a copy of the recorded notifications.
+func (r *recorder) Notifications() []sagas.Notification {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]sagas.Notification(nil), r.notifications...)
+}
+
+// Path returns the recorded notifications in the "name:Event" format.
+func (r *recorder) Path() []string {
+	notifications := r.Notifications()
+	path := make([]string, len(notifications))
+	for i, n := range notifications {
+		path[i] = sagas.IdentifierName(n.Identifier) + ":" + n.Event.String()
+	}
+	return path
+}
+
+// record appends the notification to the recorded ones.
+func (r *recorder) record(_ context.Context, notification sagas.Notification) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.notifications = append(r.notifications, notification)
+}
@@ -0,0 +1,23 @@
+package sagastest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rilder-almeida/sagas"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewRecorder(t *testing.T) {
+	t.Parallel()
+	recorder := NewRecorder()
+	step := NewStep("reserve", Succeed())
+	saga := sagas.NewSaga(sagas.WithSagaObserver(recorder))
+	saga.AddSteps(step)
+
+	saga.Run(context.Background(), func() bool { return step.GetState() == sagas.Completed })
+
+	assert.Equal(t, []string{"reserve:Running", "reserve:Successed", "reserve:Completed"}, recorder.Path())
+	assert.Len(t, recorder.Notifications(), 3)
+	assert.Equal(t, step.GetIdentifier(), recorder.Notifications()[0].Identifier)
+}
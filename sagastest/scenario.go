@@ -0,0 +1,136 @@
+package sagastest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rilder-almeida/sagas"
+)
+
+// ErrInjected is the error returned by an attempt whose fault is FaultError.
+var ErrInjected = errors.New("sagastest: injected fault")
+
+// Fault is a fault injected in an attempt of a step. It can be one of the following: FaultError, FaultPanic,
+// FaultTimeout, FaultSlow.
+type Fault int
+
+const (
+	// FaultError makes the attempt return ErrInjected without running the action.
+	FaultError Fault = iota
+	// FaultPanic makes the attempt panic without running the action. The step recovers the panic as an error
+	// that wraps sagas.ErrActionPanicked, so the attempt fails.
+	FaultPanic
+	// FaultTimeout runs the action and then makes the attempt return context.DeadlineExceeded, as a call whose
+	// effect happened but whose caller gave up waiting for it.
+	FaultTimeout
+	// FaultSlow delays the attempt before running the action, changing the order of the concurrent steps.
+	FaultSlow
+)
+
+// String returns the name of the fault.
+func (f Fault) String() string {
+	switch f {
+	case FaultError:
+		return "error"
+	case FaultPanic:
+		return "panic"
+	case FaultTimeout:
+		return "timeout"
+	case FaultSlow:
+		return "slow"
+	default:
+		return "unknown"
+	}
+}
+
+// Injection is a fault injected in a given attempt of a step.
+type Injection struct {
+	// Step is the name the step was given to the Injector.
+	Step string
+	// Attempt is the number of the attempt, starting at 1, counted across every run of the step.
+	Attempt int
+	// Fault is the fault injected in the attempt.
+	Fault Fault
+}
+
+// String returns the injection in the "step#attempt:fault" format.
+func (i Injection) String() string {
+	return fmt.Sprintf("%s#%d:%s", i.Step, i.Attempt, i.Fault)
+}
+
+// Scenario is the set of faults injected in a run of a saga, at most one per step.
+type Scenario []Injection
+
+// String returns the injections of the scenario, or "no faults" if there are none.
+func (s Scenario) String() string {
+	if len(s) == 0 {
+		return "no faults"
+	}
+
+	injections := make([]string, len(s))
+	for i, injection := range s {
+		injections[i] = injection.String()
+	}
+	return strings.Join(injections, ", ")
+}
+
+// without returns a copy of the scenario without the injection of the given index.
+func (s Scenario) without(index int) Scenario {
+	scenario := make(Scenario, 0, len(s)-1)
+	return append(append(scenario, s[:index]...), s[index+1:]...)
+}
+
+// Injector wraps the action of the step of the given name, so the faults of the scenario under simulation are
+// injected in its attempts.
+type Injector func(step string, action sagas.ActionFn) sagas.ActionFn
+
+// newInjector returns the Injector of the scenario, which delays the slow attempts by the given duration on the
+// clock. The names of the wrapped steps are added to the given list, in the order they are wrapped, and the
+// running attempts are counted.
+func newInjector(scenario Scenario, slow time.Duration, clock sagas.Clock, steps *[]string,
+	running *atomic.Int64) Injector {
+	return func(step string, action sagas.ActionFn) sagas.ActionFn {
+		*steps = append(*steps, step)
+
+		faults := make(map[int]Fault)
+		for _, injection := range scenario {
+			if injection.Step == step {
+				faults[injection.Attempt] = injection.Fault
+			}
+		}
+
+		var attempts atomic.Int64
+		return func(ctx context.Context) error {
+			running.Add(1)
+			defer running.Add(-1)
+
+			fault, ok := faults[int(attempts.Add(1))]
+			if !ok {
+				return action(ctx)
+			}
+
+			switch fault {
+			case FaultError:
+				return ErrInjected
+			case FaultPanic:
+				panic(ErrInjected)
+			case FaultTimeout:
+				if err := action(ctx); err != nil {
+					return err
+				}
+				return context.DeadlineExceeded
+			default:
+				select {
+				case <-clock.After(slow):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				return action(ctx)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package sagastest
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rilder-almeida/sagas"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Scenario_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		scenario Scenario
+		want     string
+	}{
+		{
+			name:     "[SUCCESS] Should describe the injections",
+			scenario: Scenario{{Step: "charge", Attempt: 1, Fault: FaultError}, {Step: "release", Attempt: 2, Fault: FaultSlow}},
+			want:     "charge#1:error, release#2:slow",
+		},
+
+		{
+			name:     "[SUCCESS] Should describe a scenario without faults",
+			scenario: nil,
+			want:     "no faults",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.want, test.scenario.String())
+		})
+	}
+}
+
+func Test_newInjector(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		fault     Fault
+		attempt   int
+		wantErrs  []error
+		wantCalls int
+		wantPanic bool
+	}{
+		{
+			name:      "[SUCCESS] Should return the injected error on the given attempt only",
+			fault:     FaultError,
+			attempt:   2,
+			wantErrs:  []error{nil, ErrInjected, nil},
+			wantCalls: 2,
+		},
+
+		{
+			name:      "[SUCCESS] Should run the action and time out",
+			fault:     FaultTimeout,
+			attempt:   1,
+			wantErrs:  []error{context.DeadlineExceeded},
+			wantCalls: 1,
+		},
+
+		{
+			name:      "[SUCCESS] Should run the action after a delay",
+			fault:     FaultSlow,
+			attempt:   1,
+			wantErrs:  []error{nil},
+			wantCalls: 1,
+		},
+
+		{
+			name:      "[PANIC] Should panic without running the action",
+			fault:     FaultPanic,
+			attempt:   1,
+			wantPanic: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var steps []string
+			var running atomic.Int64
+			scenario := Scenario{{Step: "step", Attempt: test.attempt, Fault: test.fault}}
+			inject := newInjector(scenario, time.Millisecond, sagas.NewSystemClock(), &steps, &running)
+
+			calls := 0
+			action := inject("step", func(context.Context) error { calls++; return nil })
+			assert.Equal(t, []string{"step"}, steps)
+
+			if test.wantPanic {
+				assert.Panics(t, func() { _ = action(context.Background()) })
+				assert.Zero(t, calls)
+				return
+			}
+
+			for _, want := range test.wantErrs {
+				assert.Equal(t, want, action(context.Background()))
+			}
+			assert.Equal(t, test.wantCalls, calls)
+			assert.Zero(t, running.Load())
+		})
+	}
+}
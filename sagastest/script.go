@@ -0,0 +1,81 @@
+/*
+The sagastest package provides helpers to test sagas: steps whose outcomes are scripted per attempt, a recorder of
+the notifications of the steps and assertions on the path the saga took.
+
+	reserve := sagastest.NewStep("reserve", sagastest.Succeed())
+	charge := sagastest.NewStep("charge", sagastest.Fail(errDeclined))
+	release := sagastest.NewStep("release", sagastest.Succeed(), sagas.WithStepCompensation())
+
+	recorder := sagastest.NewRecorder()
+	saga := sagas.NewSaga(sagas.WithSagaObserver(recorder))
+
+	...
+
+	sagastest.AssertPath(t, recorder, "reserve:Successed", "charge:Failed", "release:Completed")
+
+The Simulator runs a saga under every combination of injected faults, or a seeded sample of them, and reports the
+smallest combination that breaks one of its invariants.
+*/
+package sagastest
+
+import (
+	"context"
+
+	"github.com/rilder-almeida/sagas"
+)
+
+// outcome is the result of a single attempt of a scripted step.
+type outcome struct {
+	err    error
+	output []byte
+}
+
+// Script is the sequence of outcomes of the attempts of a scripted step. Each attempt takes the next outcome of
+// the script and the last outcome is repeated once the script is over.
+type Script struct {
+	outcomes []outcome
+}
+
+// Succeed returns a Script whose attempt succeeds.
+func Succeed() Script {
+	return Script{outcomes: []outcome{{}}}
+}
+
+// SucceedWith returns a Script whose attempt succeeds and sets the given output.
+func SucceedWith(output []byte) Script {
+	return Script{outcomes: []outcome{{output: output}}}
+}
+
+// Fail returns a Script whose attempt fails with the given error.
+func Fail(err error) Script {
+	return Script{outcomes: []outcome{{err: err}}}
+}
+
+// Skip returns a Script whose attempt is skipped.
+func Skip() Script {
+	return Fail(sagas.ErrSkipped)
+}
+
+// Then returns a Script whose attempts follow the ones of the receiver with the ones of the next script.
+// Example:
+//
+//	script := sagastest.Fail(errUnavailable).Then(sagastest.Fail(errUnavailable)).Then(sagastest.Succeed())
+//
+// The above example will create a script that fails twice and then succeeds.
+func (s Script) Then(next Script) Script {
+	outcomes := make([]outcome, 0, len(s.outcomes)+len(next.outcomes))
+	return Script{outcomes: append(append(outcomes, s.outcomes...), next.outcomes...)}
+}
+
+// run returns the result of the attempt of the given index.
+func (s Script) run(ctx context.Context, attempt int) error {
+	if len(s.outcomes) == 0 {
+		return nil
+	}
+
+	o := s.outcomes[min(attempt, len(s.outcomes)-1)]
+	if o.output != nil {
+		sagas.SetOutput(ctx, o.output)
+	}
+	return o.err
+}
@@ -0,0 +1,51 @@
+package sagastest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rilder-almeida/sagas"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Script_run(t *testing.T) {
+	t.Parallel()
+
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	tests := []struct {
+		name   string
+		script Script
+		want   []error
+	}{
+		{
+			name:   "[SUCCESS] Should run the outcomes in order and repeat the last one",
+			script: Fail(errFirst).Then(Fail(errSecond)).Then(Succeed()),
+			want:   []error{errFirst, errSecond, nil, nil},
+		},
+
+		{
+			name:   "[SUCCESS] Should skip the attempt",
+			script: Skip(),
+			want:   []error{sagas.ErrSkipped},
+		},
+
+		{
+			name:   "[SUCCESS] Should succeed when the script is empty",
+			script: Script{},
+			want:   []error{nil},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			for attempt, want := range test.want {
+				assert.Equal(t, want, test.script.run(context.Background(), attempt))
+			}
+		})
+	}
+}
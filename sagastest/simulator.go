@@ -0,0 +1,373 @@
+package sagastest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rilder-almeida/sagas"
+)
+
+// ErrNotEnded is the error of the failures whose saga did not end before the timeout of the run.
+var ErrNotEnded = errors.New("sagastest: saga did not end")
+
+const (
+	// endedInvariant is the name of the invariant that every saga must end.
+	endedInvariant = "saga ended"
+	// settleYields is the number of times the simulator yields to the saga, while the saga neither runs an
+	// action nor waits for the clock, before it decides that the saga is stuck.
+	settleYields = 1000
+)
+
+// epoch is the time the clock of every run starts at.
+var epoch = time.Unix(0, 0).UTC()
+
+// Invariant is a named condition that must hold after every run of a saga.
+type Invariant struct {
+	// Name describes the condition, such as "stock is restored when payment fails".
+	Name string
+	// Check returns an error if the condition does not hold.
+	Check func() error
+}
+
+// Instance is a fresh instance of the saga under simulation, along with the state its invariants check.
+type Instance struct {
+	// Saga is the saga to run. Its steps must wrap their actions with the Injector.
+	Saga sagas.Saga
+	// Ender returns true once the saga ended, whether it completed or was compensated.
+	Ender sagas.EnderFn
+	// Invariants are checked once the saga ended.
+	Invariants []Invariant
+}
+
+// Definition builds a fresh instance of the saga under simulation for every run, wrapping the actions of its
+// steps with the given Injector. The options give the saga the clock of the run, so they must be given to
+// sagas.NewSaga.
+type Definition func(inject Injector, options ...sagas.SagaOption) Instance
+
+// Failure is a run of a saga that broke an invariant.
+type Failure struct {
+	// Scenario is the set of faults injected in the run.
+	Scenario Scenario
+	// Invariant is the name of the invariant that did not hold.
+	Invariant string
+	// Err is the error returned by the invariant.
+	Err error
+}
+
+// String returns the failure in the "scenario: invariant: error" format.
+func (f Failure) String() string {
+	return fmt.Sprintf("%s: %s: %v", f.Scenario, f.Invariant, f.Err)
+}
+
+// Report is the result of a simulation.
+type Report struct {
+	// Runs is the number of runs of the saga, including the ones needed to find the minimal failure.
+	Runs int
+	// Failures are the runs that broke an invariant, in the order they were run.
+	Failures []Failure
+	// Minimal is the failure with the fewest faults that still breaks an invariant, or nil if no run failed.
+	Minimal *Failure
+}
+
+// Simulator is the interface that wraps the methods to run a saga under injected faults and check its
+// invariants after every run.
+type Simulator interface {
+	// Enumerate runs the saga with every combination of faults, from the fewest faults to the most, and
+	// reports the runs that broke an invariant.
+	Enumerate(ctx context.Context) Report
+	// Sample runs the saga with the given number of random combinations of faults, drawn from the seed of
+	// the Simulator, and reports the runs that broke an invariant.
+	Sample(ctx context.Context, runs int) Report
+}
+
+// simulator is the concrete implementation of the Simulator interface.
+type simulator struct {
+	definition Definition
+	options    simulatorOptions
+}
+
+// NewSimulator returns a new Simulator of the saga built by the definition. Every run is driven by its own
+// FakeClock, advanced while the saga waits for it, and ends before the next one starts, so the same seed always
+// gives the same report. A panic will occur if the definition is nil. Example:
+//
+//	simulator := sagastest.NewSimulator(func(inject sagastest.Injector, options ...sagas.SagaOption) sagastest.Instance {
+//		stock := 10
+//		reserve := sagas.NewStep("reserve", inject("reserve", func(context.Context) error { stock--; return nil }))
+//		charge := sagas.NewStep("charge", inject("charge", chargeCard))
+//		release := sagas.NewStep("release", func(context.Context) error { stock++; return nil })
+//
+//		saga := sagas.NewSaga(options...)
+//		...
+//
+//		return sagastest.Instance{
+//			Saga:  saga,
+//			Ender: func() bool { ... },
+//			Invariants: []sagastest.Invariant{{
+//				Name: "stock is restored when payment fails",
+//				Check: func() error {
+//					if charge.GetStatus() != sagas.Successed && stock != 10 {
+//						return fmt.Errorf("stock is %d", stock)
+//					}
+//					return nil
+//				},
+//			}},
+//		}
+//	})
+//
+//	report := simulator.Enumerate(ctx)
+//
+// The above example will run the saga with every combination of faults in the reservation and the charge, and
+// report the smallest combination that leaves the stock unbalanced.
+func NewSimulator(definition Definition, options ...SimulatorOption) Simulator {
+	if definition == nil {
+		panic(errors.New("definition cannot be nil"))
+	}
+
+	return &simulator{
+		definition: definition,
+		options:    newSimulatorOptions(options...),
+	}
+}
+
+// Enumerate runs the saga with every combination of faults.
+func (s *simulator) Enumerate(ctx context.Context) Report {
+	report := Report{}
+	steps := s.record(ctx, &report, nil)
+
+	scenarios := s.enumerate(steps, 0, nil)
+	sort.SliceStable(scenarios, func(i, j int) bool {
+		return len(scenarios[i]) < len(scenarios[j])
+	})
+
+	for _, scenario := range scenarios {
+		if ctx.Err() != nil {
+			break
+		}
+		s.record(ctx, &report, scenario)
+	}
+
+	s.minimize(ctx, &report)
+	return report
+}
+
+// Sample runs the saga with random combinations of faults.
+func (s *simulator) Sample(ctx context.Context, runs int) Report {
+	report := Report{}
+	steps := s.record(ctx, &report, nil)
+	random := rand.New(rand.NewSource(s.options.Seed))
+	injections := s.injections(steps)
+
+	for i := 0; i < runs && ctx.Err() == nil; i++ {
+		scenario := Scenario{}
+		for _, choices := range injections {
+			if random.Intn(2) == 0 && (s.options.MaxFaults <= 0 || len(scenario) < s.options.MaxFaults) {
+				scenario = append(scenario, choices[random.Intn(len(choices))])
+			}
+		}
+		s.record(ctx, &report, scenario)
+	}
+
+	s.minimize(ctx, &report)
+	return report
+}
+
+// record runs the scenario, adding the run and its failure to the report. It returns the names of the steps
+// of the saga.
+func (s *simulator) record(ctx context.Context, report *Report, scenario Scenario) []string {
+	failure, steps := s.run(ctx, scenario)
+	report.Runs++
+	if failure != nil {
+		report.Failures = append(report.Failures, *failure)
+	}
+	return steps
+}
+
+// run runs a fresh instance of the saga with the faults of the scenario and checks its invariants once the saga
+// returned. It returns the failure of the run, if any, and the names of the steps wrapped by the Injector.
+func (s *simulator) run(ctx context.Context, scenario Scenario) (*Failure, []string) {
+	var steps []string
+	var running atomic.Int64
+	clock := &runClock{FakeClock: sagas.NewFakeClock(epoch)}
+	inject := newInjector(scenario, s.options.SlowDelay, clock, &steps, &running)
+	instance := s.definition(inject, sagas.WithSagaClock(clock))
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The saga calls its ender once its starter step returned, and then until it ends, so the ender yields to
+	// the other goroutines of the run.
+	var started atomic.Bool
+	ender := func() bool {
+		started.Store(true)
+		runtime.Gosched()
+		return instance.Ender()
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		instance.Saga.Run(runCtx, ender)
+	}()
+	s.drive(clock, done, cancel, func() bool { return started.Load() && running.Load() == 0 })
+	<-done
+
+	if !instance.Ender() {
+		return &Failure{Scenario: scenario, Invariant: endedInvariant, Err: ErrNotEnded}, steps
+	}
+
+	for _, invariant := range instance.Invariants {
+		if err := invariant.Check(); err != nil {
+			return &Failure{Scenario: scenario, Invariant: invariant.Name, Err: err}, steps
+		}
+	}
+	return nil, steps
+}
+
+// drive advances the clock of the run to its next timer while the saga waits for it, until the saga returns. The
+// run is canceled once the timeout elapsed on the clock or once the saga is idle, with no action running and
+// nothing to wait for, since it cannot end anymore.
+func (s *simulator) drive(clock *runClock, done <-chan struct{}, cancel context.CancelFunc, idle func() bool) {
+	deadline := clock.Now().Add(s.options.Timeout)
+	settled := 0
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		runtime.Gosched()
+
+		switch {
+		case clock.Waiters() > 0:
+			settled = 0
+			next := clock.next()
+			if next.After(deadline) {
+				cancel()
+				return
+			}
+			clock.Advance(next.Sub(clock.Now()))
+		case idle():
+			if settled++; settled == settleYields {
+				cancel()
+				return
+			}
+		default:
+			settled = 0
+		}
+	}
+}
+
+// injections returns, for every step, the injections that can be made in it.
+func (s *simulator) injections(steps []string) [][]Injection {
+	injections := make([][]Injection, len(steps))
+	for i, step := range steps {
+		for attempt := 1; attempt <= s.options.Attempts; attempt++ {
+			for _, fault := range s.options.Faults {
+				injections[i] = append(injections[i], Injection{Step: step, Attempt: attempt, Fault: fault})
+			}
+		}
+	}
+	return injections
+}
+
+// enumerate returns every scenario with at least one fault that injects at most one fault in each of the
+// steps from the given index, added to the given scenario.
+func (s *simulator) enumerate(steps []string, index int, scenario Scenario) []Scenario {
+	if index == len(steps) {
+		if len(scenario) == 0 {
+			return nil
+		}
+		return []Scenario{append(Scenario(nil), scenario...)}
+	}
+
+	scenarios := s.enumerate(steps, index+1, scenario)
+	if s.options.MaxFaults > 0 && len(scenario) >= s.options.MaxFaults {
+		return scenarios
+	}
+
+	for _, injection := range s.injections(steps[index : index+1])[0] {
+		scenarios = append(scenarios, s.enumerate(steps, index+1, append(scenario, injection))...)
+	}
+	return scenarios
+}
+
+// minimize sets the minimal failure of the report: the failure with the fewest faults, whose faults are then
+// removed one at a time while the run still fails.
+func (s *simulator) minimize(ctx context.Context, report *Report) {
+	if len(report.Failures) == 0 {
+		return
+	}
+
+	minimal := report.Failures[0]
+	for _, failure := range report.Failures[1:] {
+		if len(failure.Scenario) < len(minimal.Scenario) {
+			minimal = failure
+		}
+	}
+
+	for shrunk := true; shrunk && ctx.Err() == nil; {
+		shrunk = false
+		for i := range minimal.Scenario {
+			failure, _ := s.run(ctx, minimal.Scenario.without(i))
+			report.Runs++
+			if failure != nil {
+				minimal, shrunk = *failure, true
+				break
+			}
+		}
+	}
+
+	report.Minimal = &minimal
+}
+
+// runClock is the FakeClock of a run. It keeps the deadlines of the timers created by the saga, so the simulator
+// advances the clock straight to the next one.
+type runClock struct {
+	sagas.FakeClock
+	// deadlines are the deadlines of the timers, some of which may be stopped already. They are guarded by the
+	// mutex.
+	deadlines []time.Time
+	mutex     sync.Mutex
+}
+
+// After sends the time of the clock on the returned channel once it is advanced by the duration.
+func (c *runClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a new Timer of the FakeClock and keeps its deadline.
+func (c *runClock) NewTimer(d time.Duration) sagas.Timer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	timer := c.FakeClock.NewTimer(d)
+	c.deadlines = append(c.deadlines, c.FakeClock.Now().Add(d))
+	return timer
+}
+
+// next returns the earliest deadline of the timers that is not past, or the time of the clock if there is none.
+// The deadline of a stopped timer may be returned, which only makes the clock advance in smaller steps.
+func (c *runClock) next() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.FakeClock.Now()
+	deadlines := c.deadlines[:0]
+	next := now
+	for _, deadline := range c.deadlines {
+		if !deadline.After(now) {
+			continue
+		}
+		deadlines = append(deadlines, deadline)
+		if next.Equal(now) || deadline.Before(next) {
+			next = deadline
+		}
+	}
+	c.deadlines = deadlines
+	return next
+}
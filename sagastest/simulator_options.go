@@ -0,0 +1,77 @@
+package sagastest
+
+import "time"
+
+type simulatorOptions struct {
+	Seed      int64
+	Faults    []Fault
+	Attempts  int
+	MaxFaults int
+	Timeout   time.Duration
+	SlowDelay time.Duration
+}
+
+type SimulatorOption func(*simulatorOptions)
+
+func newSimulatorOptions(opts ...SimulatorOption) simulatorOptions {
+	options := simulatorOptions{
+		Seed:      1,
+		Faults:    []Fault{FaultError, FaultPanic, FaultTimeout},
+		Attempts:  1,
+		MaxFaults: 0,
+		Timeout:   time.Second,
+		SlowDelay: 10 * time.Millisecond,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// WithSimulatorSeed sets the seed of the random source used to sample the scenarios, so the same scenarios are
+// run every time. By default, the seed is 1.
+func WithSimulatorSeed(seed int64) SimulatorOption {
+	return func(o *simulatorOptions) {
+		o.Seed = seed
+	}
+}
+
+// WithSimulatorFaults sets the faults injected in the steps. By default, the steps fail, panic and time out.
+func WithSimulatorFaults(faults ...Fault) SimulatorOption {
+	return func(o *simulatorOptions) {
+		o.Faults = faults
+	}
+}
+
+// WithSimulatorAttempts sets the number of attempts of each step in which a fault can be injected, which matters
+// for the steps that are retried. By default, faults are only injected in the first attempt.
+func WithSimulatorAttempts(attempts int) SimulatorOption {
+	return func(o *simulatorOptions) {
+		o.Attempts = attempts
+	}
+}
+
+// WithSimulatorMaxFaults limits the number of faults injected in a single run. By default, a fault can be
+// injected in every step of the same run.
+func WithSimulatorMaxFaults(maximum int) SimulatorOption {
+	return func(o *simulatorOptions) {
+		o.MaxFaults = maximum
+	}
+}
+
+// WithSimulatorTimeout sets the time a run can take on its clock before it is stopped and reported as a saga that
+// did not end. By default, it is one second.
+func WithSimulatorTimeout(timeout time.Duration) SimulatorOption {
+	return func(o *simulatorOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithSimulatorSlowDelay sets the delay of the attempts whose fault is FaultSlow. By default, it is 10ms.
+func WithSimulatorSlowDelay(delay time.Duration) SimulatorOption {
+	return func(o *simulatorOptions) {
+		o.SlowDelay = delay
+	}
+}
@@ -0,0 +1,204 @@
+package sagastest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rilder-almeida/sagas"
+	"github.com/stretchr/testify/assert"
+)
+
+// reservation builds a saga that reserves stock, charges the payment and releases the stock if the payment
+// fails. The release is not retried, so the stock is not restored when it fails too.
+func reservation(inject Injector, options ...sagas.SagaOption) Instance {
+	var stock atomic.Int64
+	stock.Store(10)
+
+	reserve := sagas.NewStep("reserve", inject("reserve", func(context.Context) error { stock.Add(-1); return nil }))
+	charge := sagas.NewStep("charge", inject("charge", func(context.Context) error { return nil }))
+	release := sagas.NewStep("release", inject("release", func(context.Context) error { stock.Add(1); return nil }),
+		sagas.WithStepCompensation())
+
+	saga := sagas.NewSaga(options...)
+	saga.AddSteps(reserve, charge, release)
+	saga.When(reserve).Is(sagas.Successed).Then(sagas.NewAction(charge.Run)).Plan()
+	saga.When(charge).Is(sagas.Failed).Then(sagas.NewAction(release.Run)).Plan()
+
+	ended := func(step sagas.Step, status sagas.Status) bool {
+		return step.GetState() == sagas.Completed && step.GetStatus() == status
+	}
+
+	return Instance{
+		Saga: saga,
+		Ender: func() bool {
+			return ended(reserve, sagas.Failed) || ended(charge, sagas.Successed) || release.GetState() == sagas.Completed
+		},
+		Invariants: []Invariant{{
+			Name: "stock is restored when payment fails",
+			Check: func() error {
+				if reserve.GetStatus() == sagas.Successed && charge.GetStatus() != sagas.Successed && stock.Load() != 10 {
+					return fmt.Errorf("stock is %d", stock.Load())
+				}
+				return nil
+			},
+		}},
+	}
+}
+
+func Test_NewSimulator(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { NewSimulator(nil) })
+}
+
+func Test_simulator_Enumerate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		definition    Definition
+		wantRuns      int
+		wantMinimal   Scenario
+		wantInvariant string
+	}{
+		{
+			name:       "[FAILURE] Should report the minimal scenario that breaks an invariant",
+			definition: reservation,
+			wantRuns:   10,
+			wantMinimal: Scenario{
+				{Step: "charge", Attempt: 1, Fault: FaultError},
+				{Step: "release", Attempt: 1, Fault: FaultError},
+			},
+			wantInvariant: "stock is restored when payment fails",
+		},
+
+		{
+			name: "[FAILURE] Should report a saga that does not end",
+			definition: func(inject Injector, options ...sagas.SagaOption) Instance {
+				first := sagas.NewStep("first", inject("first", func(context.Context) error { return nil }))
+				second := sagas.NewStep("second", inject("second", func(context.Context) error { return nil }))
+				saga := sagas.NewSaga(options...)
+				saga.AddSteps(first, second)
+				saga.When(first).Is(sagas.Successed).Then(sagas.NewAction(second.Run)).Plan()
+				return Instance{Saga: saga, Ender: func() bool { return second.GetState() == sagas.Completed }}
+			},
+			wantRuns:      5,
+			wantMinimal:   Scenario{{Step: "first", Attempt: 1, Fault: FaultError}},
+			wantInvariant: endedInvariant,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			simulator := NewSimulator(test.definition,
+				WithSimulatorFaults(FaultError), WithSimulatorTimeout(50*time.Millisecond))
+
+			report := simulator.Enumerate(context.Background())
+
+			assert.Equal(t, test.wantRuns, report.Runs)
+			if assert.NotNil(t, report.Minimal) {
+				assert.Equal(t, test.wantMinimal, report.Minimal.Scenario)
+				assert.Equal(t, test.wantInvariant, report.Minimal.Invariant)
+			}
+		})
+	}
+}
+
+func Test_simulator_Sample(t *testing.T) {
+	t.Parallel()
+
+	sample := func(seed int64) Report {
+		simulator := NewSimulator(reservation, WithSimulatorSeed(seed), WithSimulatorFaults(FaultError, FaultTimeout),
+			WithSimulatorTimeout(50*time.Millisecond))
+		return simulator.Sample(context.Background(), 20)
+	}
+
+	first, second := sample(7), sample(7)
+
+	assert.Equal(t, first, second)
+	if assert.NotNil(t, first.Minimal) {
+		assert.Len(t, first.Minimal.Scenario, 2)
+		assert.Equal(t, "release", first.Minimal.Scenario[1].Step)
+	}
+}
+
+func Test_simulator_Sample_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should report the same runs for the same seed with retries and slow attempts", func(t *testing.T) {
+		t.Parallel()
+		// retried builds a saga whose steps are retried after about a minute, which only passes on the clock of the
+		// run. The jitter of the retrier is seeded, so the runs of a scenario wait as long as each other.
+		retried := func(inject Injector, options ...sagas.SagaOption) Instance {
+			backoff := sagas.BackoffConstant(1, time.Minute)
+			retrier := sagas.WithStepRetrier(sagas.NewRetrier(backoff, sagas.WithRetrierRandomSeed(1)))
+			reserve := sagas.NewStep("reserve", inject("reserve", func(context.Context) error { return nil }), retrier)
+			charge := sagas.NewStep("charge", inject("charge", func(context.Context) error { return nil }), retrier)
+			saga := sagas.NewSaga(options...)
+			saga.AddSteps(reserve, charge)
+			saga.When(reserve).Is(sagas.Successed).Then(sagas.NewAction(charge.Run)).Plan()
+			return Instance{
+				Saga:  saga,
+				Ender: func() bool { return charge.GetState() == sagas.Completed || reserve.GetStatus() == sagas.Failed },
+				Invariants: []Invariant{{
+					Name: "charge succeeds",
+					Check: func() error {
+						if charge.GetStatus() != sagas.Successed {
+							return errors.New("charge did not succeed")
+						}
+						return nil
+					},
+				}},
+			}
+		}
+		sample := func() Report {
+			simulator := NewSimulator(retried, WithSimulatorSeed(3), WithSimulatorAttempts(2),
+				WithSimulatorFaults(FaultError, FaultSlow, FaultTimeout), WithSimulatorTimeout(2*time.Minute))
+			return simulator.Sample(context.Background(), 30)
+		}
+
+		first, second := sample(), sample()
+
+		assert.Equal(t, first, second)
+		assert.NotEmpty(t, first.Failures)
+	})
+}
+
+func Test_simulator_Enumerate_Panic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[FAILURE] Should fail the step whose attempt panics", func(t *testing.T) {
+		t.Parallel()
+		definition := func(inject Injector, options ...sagas.SagaOption) Instance {
+			charge := sagas.NewStep("charge", inject("charge", func(context.Context) error { return nil }))
+			saga := sagas.NewSaga(options...)
+			saga.AddSteps(charge)
+			return Instance{
+				Saga:  saga,
+				Ender: func() bool { return charge.GetState() == sagas.Completed },
+				Invariants: []Invariant{{
+					Name: "charge does not fail",
+					Check: func() error {
+						if charge.GetStatus() == sagas.Failed {
+							return errors.New("charge failed")
+						}
+						return nil
+					},
+				}},
+			}
+		}
+		simulator := NewSimulator(definition, WithSimulatorFaults(FaultPanic), WithSimulatorTimeout(50*time.Millisecond))
+
+		report := simulator.Enumerate(context.Background())
+
+		if assert.NotNil(t, report.Minimal) {
+			assert.Equal(t, Scenario{{Step: "charge", Attempt: 1, Fault: FaultPanic}}, report.Minimal.Scenario)
+			assert.Equal(t, "charge does not fail", report.Minimal.Invariant)
+		}
+	})
+}
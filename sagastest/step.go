@@ -0,0 +1,54 @@
+package sagastest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rilder-almeida/sagas"
+)
+
+// ScriptedStep is a sagas.Step whose attempts follow a Script.
+type ScriptedStep interface {
+	sagas.Step
+	// Calls returns the number of attempts of the step.
+	Calls() int
+}
+
+// scriptedStep is the concrete implementation of the ScriptedStep interface.
+type scriptedStep struct {
+	sagas.Step
+	script Script
+	calls  int
+	mutex  sync.Mutex
+}
+
+// NewStep returns a new ScriptedStep with the given name, whose attempts follow the script. The options are
+// given to sagas.NewStep, so the step can have a retrier, be a compensation or have a stable identifier. A panic
+// will occur if the name is empty. Example:
+//
+//	charge := sagastest.NewStep("charge", sagastest.Fail(errUnavailable).Then(sagastest.Succeed()),
+//		sagas.WithStepRetrier(sagas.NewRetrier(sagas.BackoffConstant(1, time.Millisecond))))
+//
+// The above example will create a step that fails its first attempt and succeeds when it is retried.
+func NewStep(name string, script Script, options ...sagas.StepOption) ScriptedStep {
+	s := &scriptedStep{script: script}
+	s.Step = sagas.NewStep(name, s.attempt, options...)
+	return s
+}
+
+// Calls returns the number of attempts of the step.
+func (s *scriptedStep) Calls() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls
+}
+
+// attempt runs the next outcome of the script.
+func (s *scriptedStep) attempt(ctx context.Context) error {
+	s.mutex.Lock()
+	attempt := s.calls
+	s.calls++
+	s.mutex.Unlock()
+
+	return s.script.run(ctx, attempt)
+}
@@ -0,0 +1,65 @@
+package sagastest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rilder-almeida/sagas"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewStep(t *testing.T) {
+	t.Parallel()
+
+	errUnavailable := errors.New("unavailable")
+
+	tests := []struct {
+		name       string
+		script     Script
+		options    []sagas.StepOption
+		wantStatus sagas.Status
+		wantCalls  int
+		wantOutput []byte
+	}{
+		{
+			name:       "[SUCCESS] Should succeed with the output of the script",
+			script:     SucceedWith([]byte("ok")),
+			wantStatus: sagas.Successed,
+			wantCalls:  1,
+			wantOutput: []byte("ok"),
+		},
+
+		{
+			name:   "[SUCCESS] Should follow the script on every retry",
+			script: Fail(errUnavailable).Then(Fail(errUnavailable)).Then(Succeed()),
+			options: []sagas.StepOption{
+				sagas.WithStepRetrier(sagas.NewRetrier(sagas.BackoffConstant(3, time.Millisecond))),
+			},
+			wantStatus: sagas.Successed,
+			wantCalls:  3,
+		},
+
+		{
+			name:       "[FAILURE] Should fail with the error of the script",
+			script:     Fail(errUnavailable),
+			wantStatus: sagas.Failed,
+			wantCalls:  1,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			s := NewStep("step", test.script, test.options...)
+
+			_ = s.Run(context.Background())
+
+			assert.Equal(t, test.wantStatus, s.GetStatus())
+			assert.Equal(t, test.wantCalls, s.Calls())
+			assert.Equal(t, test.wantOutput, s.Output())
+		})
+	}
+}
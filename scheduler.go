@@ -0,0 +1,93 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Scheduler is the interface that wraps the methods to run functions in the future. It is used by the delayed
+// steps to schedule their transitions instead of blocking while they wait.
+type Scheduler interface {
+	// Now returns the current time of the Scheduler.
+	Now() time.Time
+	// Schedule runs the function with the context at the given time, or right away if the time already
+	// passed. The function is not run if the context is done before the time.
+	Schedule(ctx context.Context, at time.Time, fn func(context.Context))
+}
+
+// scheduler is the concrete implementation of the Scheduler interface.
+type scheduler struct {
+	clock Clock
+}
+
+// defaultScheduler is the Scheduler used when none is provided.
+var defaultScheduler = NewScheduler(NewSystemClock())
+
+// NewScheduler returns a new Scheduler that reads the time and waits for it with the given Clock. A panic will
+// occur if the clock is nil. Example:
+//
+//	scheduler := sagas.NewScheduler(sagas.NewSystemClock())
+//
+//	saga := sagas.NewSaga(sagas.WithSagaScheduler(scheduler))
+//
+// The above example will create a saga whose delayed steps are scheduled at the time of the system.
+func NewScheduler(clock Clock) Scheduler {
+	if clock == nil {
+		panic(errors.New("clock cannot be nil"))
+	}
+
+	return &scheduler{
+		clock: clock,
+	}
+}
+
+// Now returns the current time of the clock.
+func (s *scheduler) Now() time.Time {
+	return s.clock.Now()
+}
+
+// Schedule waits for the time on the clock in the background and then runs the function.
+func (s *scheduler) Schedule(ctx context.Context, at time.Time, fn func(context.Context)) {
+	timer := s.clock.NewTimer(at.Sub(s.clock.Now()))
+	go func() {
+		select {
+		case <-timer.C():
+			fn(ctx)
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}()
+}
+
+// schedulerKey is the context key of the Scheduler of the saga.
+type schedulerKey struct{}
+
+// withScheduler returns a copy of the context that carries the given Scheduler.
+func withScheduler(ctx context.Context, scheduler Scheduler) context.Context {
+	return context.WithValue(ctx, schedulerKey{}, scheduler)
+}
+
+// schedulerFromContext returns the Scheduler carried by the context. If there is none, it returns a Scheduler
+// that uses the time of the system.
+func schedulerFromContext(ctx context.Context) Scheduler {
+	if scheduler, ok := ctx.Value(schedulerKey{}).(Scheduler); ok && scheduler != nil {
+		return scheduler
+	}
+	return defaultScheduler
+}
+
+// scheduledKey is the context key that marks the run of a delayed step at its scheduled time.
+type scheduledKey struct{}
+
+// withScheduled returns a copy of the context that marks the run of the given delayed step at its scheduled
+// time, so it is not delayed again. The mark only applies to that step, not to the steps it triggers.
+func withScheduled(ctx context.Context, step Identifier) context.Context {
+	return context.WithValue(ctx, scheduledKey{}, step)
+}
+
+// isScheduled reports whether the context marks the run of the given delayed step at its scheduled time.
+func isScheduled(ctx context.Context, step Identifier) bool {
+	scheduled, ok := ctx.Value(scheduledKey{}).(Identifier)
+	return ok && scheduled != nil && step != nil && scheduled.String() == step.String()
+}
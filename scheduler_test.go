@@ -0,0 +1,71 @@
+package sagas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewScheduler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		at      time.Duration
+		cancel  bool
+		wantRun bool
+	}{
+		{
+			name:    "[SUCCESS] Should run the function at the given time",
+			at:      20 * time.Millisecond,
+			wantRun: true,
+		},
+
+		{
+			name:    "[SUCCESS] Should run the function right away when the time already passed",
+			at:      -time.Hour,
+			wantRun: true,
+		},
+
+		{
+			name:    "[FAILURE] Should not run the function when the context is done",
+			at:      time.Hour,
+			cancel:  true,
+			wantRun: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			scheduler := NewScheduler(NewSystemClock())
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ran := make(chan time.Time, 1)
+			at := scheduler.Now().Add(test.at)
+			scheduler.Schedule(ctx, at, func(context.Context) {
+				ran <- scheduler.Now()
+			})
+			if test.cancel {
+				cancel()
+			}
+
+			select {
+			case now := <-ran:
+				assert.True(t, test.wantRun)
+				assert.False(t, now.Before(at))
+			case <-time.After(200 * time.Millisecond):
+				assert.False(t, test.wantRun)
+			}
+		})
+	}
+}
+
+func Test_NewScheduler_Panic(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { NewScheduler(nil) })
+}
@@ -0,0 +1,66 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+)
+
+// signalHub wakes the steps of a saga instance that wait for signals. The signals themselves are kept in the
+// SagaStore, so the hub only tells the waiting steps that a signal of their name may be available.
+type signalHub struct {
+	wakes map[string]chan struct{}
+	mutex sync.Mutex
+}
+
+// newSignalHub returns a new signalHub with no waiting steps.
+func newSignalHub() *signalHub {
+	return &signalHub{
+		wakes: make(map[string]chan struct{}),
+	}
+}
+
+// channel returns the channel that is closed on the next signal of the name. It must be taken before looking
+// for the signal in the SagaStore, so a signal sent in between is not missed.
+func (h *signalHub) channel(name string) <-chan struct{} {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	wake, ok := h.wakes[name]
+	if !ok {
+		wake = make(chan struct{})
+		h.wakes[name] = wake
+	}
+	return wake
+}
+
+// notify wakes the steps waiting for a signal of the name.
+func (h *signalHub) notify(name string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if wake, ok := h.wakes[name]; ok {
+		close(wake)
+		delete(h.wakes, name)
+	}
+}
+
+// parking is the parking of a saga instance: the store of its parked state and the hub of its signals. It is
+// carried by the context given to the steps, so they can park themselves.
+type parking struct {
+	store SagaStore
+	hub   *signalHub
+}
+
+// parkingKey is the context key of the parking of the running saga.
+type parkingKey struct{}
+
+// withParking returns a copy of the context that carries the given parking.
+func withParking(ctx context.Context, p parking) context.Context {
+	return context.WithValue(ctx, parkingKey{}, p)
+}
+
+// parkingFromContext returns the parking of the running saga carried by the context, if any.
+func parkingFromContext(ctx context.Context) (parking, bool) {
+	p, ok := ctx.Value(parkingKey{}).(parking)
+	return p, ok
+}
@@ -3,6 +3,9 @@ package sagas
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"sync"
+	"time"
 )
 
 // Step is an interface that represents a abstract implementation of a step. Step is a unit of work that can be executed and retried.
@@ -14,6 +17,10 @@ type Step interface {
 	GetStatus() Status
 	// GetState returns the current status of the Step.
 	GetState() State
+	// Attempts returns the attempts performed by the last execution of the Step.
+	Attempts() []Attempt
+	// Output returns the output set by the action of the last execution of the Step.
+	Output() []byte
 	// Run executes the Step's actionFn and returns the result. If the Step has a retrier,
 	Run(context.Context) error
 	// getNotifier returns the notifier that will be used to notify events that occur in the Step.
@@ -35,6 +42,39 @@ type step struct {
 	state State
 	// notifier is the notifier that will be used to notify events
 	notfier Notifier
+	// limiter caps the amount of attempts per second of the Step, including the retries. It is optional.
+	limiter RateLimiter
+	// tracer is the tracer of the Step. If it is nil, the tracer of the saga is used.
+	tracer Tracer
+	// compensation indicates whether the Step compensates other steps.
+	compensation bool
+	// logger is the logger of the Step. If it is nil, the logger of the saga is used.
+	logger *slog.Logger
+	// idempotencyStore keeps the results of the Step. If it is nil, the store of the saga is used.
+	idempotencyStore IdempotencyStore
+	// attempts is the history of attempts of the last execution of the Step.
+	attempts []Attempt
+	// output is the output set by the action of the last execution of the Step.
+	output []byte
+	// mutex is used to protect the status, the state, the attempts and the output.
+	mutex sync.Mutex
+	// policy determines what the Step does when it is triggered again.
+	policy ExecutionPolicy
+	// queue holds the execution of a Step with the Serialize policy.
+	queue chan struct{}
+	// running and ran indicate whether the Step is running and whether it has completed an execution.
+	running, ran bool
+	// guard is used to protect running and ran.
+	guard sync.Mutex
+	// notBefore and delay postpone the run of the Step once it is triggered.
+	notBefore time.Time
+	delay     time.Duration
+	// scheduler schedules the postponed runs of the Step. If it is nil, the scheduler of the saga is used.
+	scheduler Scheduler
+	// clock times the Step. If it is nil, the clock of the saga is used.
+	clock Clock
+	// middlewares wrap every attempt of the Step, inside the middlewares of the saga.
+	middlewares []ActionMiddleware
 }
 
 // NewStep creates a new Step with the given name and actionFn. The name is used to identify the Step.
@@ -50,13 +90,33 @@ func NewStep(name string, action ActionFn, options ...StepOption) Step {
 
 	stepOptions := newStepOptions(options...)
 
+	identifier := stepOptions.Identifier
+	if identifier == nil && stepOptions.Clock != nil {
+		identifier = NewIdentifierWithClock(name, stepOptions.Clock)
+	}
+	if identifier == nil {
+		identifier = NewIdentifier(name)
+	}
+
 	return &step{
-		identifier: NewIdentifier(name),
-		action:     NewAction(action),
-		retrier:    stepOptions.Retrier,
-		status:     stepOptions.Status,
-		state:      stepOptions.State,
-		notfier:    stepOptions.Notifier,
+		identifier:       identifier,
+		action:           NewAction(action),
+		retrier:          stepOptions.Retrier,
+		status:           stepOptions.Status,
+		state:            stepOptions.State,
+		notfier:          stepOptions.Notifier,
+		limiter:          stepOptions.RateLimiter,
+		tracer:           stepOptions.Tracer,
+		compensation:     stepOptions.Compensation,
+		logger:           stepOptions.Logger,
+		idempotencyStore: stepOptions.IdempotencyStore,
+		policy:           stepOptions.ExecutionPolicy,
+		queue:            make(chan struct{}, 1),
+		notBefore:        stepOptions.NotBefore,
+		delay:            stepOptions.Delay,
+		scheduler:        stepOptions.Scheduler,
+		clock:            stepOptions.Clock,
+		middlewares:      stepOptions.Middlewares,
 	}
 }
 
@@ -67,65 +127,428 @@ func (s *step) GetIdentifier() Identifier {
 
 // GetStatus returns the current status of the Step.
 func (s *step) GetStatus() Status {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	return s.status
 }
 
 // GetState returns the current status of the Step.
 func (s *step) GetState() State {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	return s.state
 }
 
+// Attempts returns a copy of the attempts performed by the last execution of the Step. Each
+// attempt holds its start and end times, its error and the delay waited before the next one.
+func (s *step) Attempts() []Attempt {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Attempt(nil), s.attempts...)
+}
+
+// Output returns a copy of the output set by the action of the last execution of the Step with
+// SetOutput or restored from its IdempotencyStore.
+func (s *step) Output() []byte {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]byte(nil), s.output...)
+}
+
 // Run executes the Step's actionFn and returns the result. If the Step has a retrier,
 // it will be used to retry the actionFn if it fails. If the Step fails, it will be
 // set to a failed state. If the Step succeeds, it will be set to a succeed state.
+// If the context is canceled, it will be set to a canceled state, and if the action
+// is skipped, it will be set to a skipped state and no error is returned.
 // If the Step is in a failed state, it can be rollforward. If the Step is in a
 // succeed state, it can be rollbackwarded.
+// Every attempt receives the idempotency key of the Step through the context. If the
+// Step has an IdempotencyStore that recorded it as succeeded, the action is not run
+// again and its stored output is restored instead.
+// A Step that is triggered again is handled according to its ExecutionPolicy.
+// A Step with a delay or a not-before time that is triggered early is scheduled to
+// run later and Run returns right away, unless its ExecutionPolicy refuses the trigger.
 func (s *step) Run(ctx context.Context) error {
-	defer s.setState(ctx, Completed)
+	if s.clock != nil {
+		ctx = withClock(ctx, s.clock)
+	}
+
+	if scheduled, err := s.schedule(ctx); scheduled || err != nil {
+		return err
+	}
+	if isScheduled(ctx, s.identifier) {
+		// The steps triggered by this run, including this Step, must be delayed again.
+		ctx = withScheduled(ctx, nil)
+	}
+
+	release, err := s.acquire(ctx)
+	if release == nil {
+		return err
+	}
+	defer release()
+	return s.execute(ctx)
+}
+
+// acquire applies the execution policy of the Step before it runs. It returns the function that
+// releases the Step once it ran or, if the Step must not run, a nil function along with the
+// error that Run should return.
+func (s *step) acquire(ctx context.Context) (func(), error) {
+	if s.policy == Serialize {
+		select {
+		case s.queue <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	s.guard.Lock()
+	ignored, rejected := s.refused()
+	if !ignored && !rejected {
+		s.running = true
+	}
+	s.guard.Unlock()
+
+	if ignored || rejected {
+		return nil, s.refuse(ctx, rejected)
+	}
+
+	return func() {
+		s.guard.Lock()
+		s.running, s.ran = false, true
+		s.guard.Unlock()
+		if s.policy == Serialize {
+			<-s.queue
+		}
+	}, nil
+}
+
+// refused reports whether the execution policy of the Step ignores or rejects a trigger received now. The
+// guard must be held by the caller.
+func (s *step) refused() (ignored, rejected bool) {
+	return s.policy == RunOnce && (s.running || s.ran), s.policy == Reject && s.running
+}
+
+// refuse handles a trigger that the execution policy of the Step ignores or rejects. It returns the error that
+// Run should return.
+func (s *step) refuse(ctx context.Context, rejected bool) error {
+	if rejected {
+		s.notfier.Notify(ctx, s.notification(ctx, Rejected))
+		return ErrStepRunning
+	}
+	logContext(withStepIdentifier(ctx, s.identifier), slog.LevelDebug, "step trigger ignored")
+	return nil
+}
+
+// execute runs the Step regardless of its execution policy. The status of the Step is notified once
+// the Step finished, so the actions it triggers run after its result is recorded and its hooks are called. A
+// Step that is left parked when the context is done, such as a wait step, is not notified as completed, so it
+// does not trigger the actions planned for its end before it is resumed.
+func (s *step) execute(ctx context.Context) (err error) {
+	defer func() {
+		if ctx.Err() != nil && s.GetState() == Waiting {
+			return
+		}
+		s.notfier.Notify(ctx, s.notification(ctx, s.GetStatus()))
+		s.setState(ctx, Completed)
+	}()
+	s.resetAttempts()
 	s.setState(ctx, Running)
+
+	saga, _ := sagaIdentifierFromContext(ctx)
+	clock := clockFromContext(ctx)
+	metrics, startedAt := metricsFromContext(ctx), clock.Now()
+	if s.compensation {
+		metrics.SagaCompensated(saga, s.identifier)
+	}
+
+	actionCtx, span := s.startSpan(ctx)
+	hooks := hooksFromContext(ctx)
+	info := StepInfo{Saga: saga, Step: s.identifier, Compensation: s.compensation}
+	hooks.stepStart(actionCtx, info)
+	defer func() {
+		duration := clock.Now().Sub(startedAt)
+		span.End(err)
+		metrics.StepFinished(s.identifier, s.GetStatus(), duration)
+		logContext(actionCtx, slog.LevelDebug, "step finished",
+			slog.String("status", s.GetStatus().String()), slog.Duration("duration", duration))
+
+		info.Attempt, info.Status, info.Err, info.Duration = len(s.Attempts()), s.GetStatus(), err, duration
+		hooks.stepEnd(actionCtx, info)
+	}()
+
+	key := IdempotencyKey(saga, s.identifier)
+	actionCtx = withOutputRecorder(withIdempotencyKey(actionCtx, key), s)
+
+	store := s.storeOf(ctx)
+	if store != nil {
+		record, ok, err := store.Load(actionCtx, key)
+		if err != nil {
+			return s.finish(ctx, err)
+		}
+		if ok && record.Status == Successed {
+			s.setOutput(record.Output)
+			logContext(actionCtx, slog.LevelDebug, "step already succeeded")
+			return s.finish(ctx, nil)
+		}
+	}
+
 	if s.retrier != nil {
-		return s.runWithRetry(ctx)
+		err = s.runWithRetry(ctx, actionCtx)
+	} else {
+		err = s.run(ctx, actionCtx)
+	}
+
+	if store != nil && s.GetStatus() == Successed {
+		s.saveRecord(actionCtx, store, key)
 	}
-	return s.run(ctx)
+	return err
 }
 
-func (s *step) run(ctx context.Context) error {
-	err := s.action.run(ctx)
-	if err != nil {
-		s.setStatus(ctx, Failed)
-		return err
+func (s *step) run(ctx context.Context, actionCtx context.Context) error {
+	if s.limiter != nil {
+		if err := s.limiter.Acquire(actionCtx); err != nil {
+			return s.finish(ctx, err)
+		}
 	}
+	attemptCtx, span := tracerFromContext(actionCtx).StartAttempt(withAttemptNumber(actionCtx, 1), s.identifier, 1)
+	clock := clockFromContext(ctx)
+	attempt := Attempt{Number: 1, StartedAt: clock.Now()}
+	err := s.wrapAction(ctx).run(attemptCtx)
+	attempt.EndedAt, attempt.Err = clock.Now(), err
+	attempt.Status, _ = resultStatus(ctx, err)
+	span.End(err)
+	s.recordAttempt(ctx, attempt, false)
+	return s.finish(ctx, err)
+}
 
-	s.setStatus(ctx, Successed)
-	return nil
+// runWithRetry runs the attempts of the Step with its retrier. The rate limiter of the Step, if any, is
+// acquired before each attempt, whatever the retrier. Once the limiter refuses an attempt, the retries are
+// canceled and the Step fails with the error of the limiter joined to the error of the last attempt.
+func (s *step) runWithRetry(ctx context.Context, actionCtx context.Context) error {
+	if s.limiter == nil {
+		err := s.retrier.Retry(withAttemptRecorder(actionCtx, s), s.wrapAction(ctx))
+		return s.finish(ctx, err)
+	}
+
+	retryCtx, cancel := context.WithCancel(actionCtx)
+	defer cancel()
+	attempts := &limitedAttempts{step: s, action: s.wrapAction(ctx), cancel: cancel}
+	err := s.retrier.Retry(withAttemptRecorder(retryCtx, attempts), NewAction(attempts.run))
+	if refused := attempts.refusal(); refused != nil {
+		err = refused
+	}
+	return s.finish(ctx, err)
 }
 
-func (s *step) runWithRetry(ctx context.Context) error {
-	err := s.retrier.Retry(ctx, s.action)
-	if err != nil {
-		s.setStatus(ctx, Failed)
-		return err
+// limitedAttempts runs the attempts of a Step given to its retrier, acquiring the rate limiter of the Step
+// before each of them. The attempt refused by the limiter is not recorded by the Step.
+type limitedAttempts struct {
+	step   *step
+	action Action
+	cancel context.CancelFunc
+	// last is the error of the last attempt and refused is the error returned once the limiter refused an
+	// attempt. They are guarded by the mutex.
+	last    error
+	refused error
+	mutex   sync.Mutex
+}
+
+// run acquires the rate limiter of the Step and runs the attempt. If the limiter refuses the attempt, the
+// retries are canceled.
+func (a *limitedAttempts) run(ctx context.Context) error {
+	if err := a.step.limiter.Acquire(ctx); err != nil {
+		a.mutex.Lock()
+		a.refused = errors.Join(err, a.last)
+		refused := a.refused
+		a.mutex.Unlock()
+		a.cancel()
+		return refused
 	}
 
-	s.setStatus(ctx, Successed)
-	return nil
+	err := a.action.run(ctx)
+	a.mutex.Lock()
+	a.last = err
+	a.mutex.Unlock()
+	return err
 }
 
-// getNotifier returns the notifier that will be used to notify
-func (s *step) getNotifier() Notifier {
-	return s.notfier
+// refusal returns the error returned once the limiter refused an attempt, if it did.
+func (a *limitedAttempts) refusal() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.refused
+}
+
+// recordAttempt records the attempt in the Step, unless the limiter refused it.
+func (a *limitedAttempts) recordAttempt(ctx context.Context, attempt Attempt, retrying bool) {
+	if a.refusal() != nil {
+		return
+	}
+	a.step.recordAttempt(ctx, attempt, retrying)
+}
+
+// wrapAction returns the action of the Step wrapped by the middlewares of the saga, carried by the
+// context, and then by the middlewares of the Step. The middlewares run inside the recovery of the
+// action, so they see its panics.
+func (s *step) wrapAction(ctx context.Context) Action {
+	middlewares := append(append([]ActionMiddleware(nil), middlewaresFromContext(ctx)...), s.middlewares...)
+	if len(middlewares) == 0 {
+		return s.action
+	}
+	return NewAction(chainMiddlewares(actionFnOf(s.action), middlewares...))
+}
+
+// startSpan starts the span of the Step with its own tracer or, if it has none, with the tracer
+// carried by the context. It returns the context given to the action, which carries the span, the
+// tracer, the logger and the identifier of the Step.
+func (s *step) startSpan(ctx context.Context) (context.Context, Span) {
+	tracer := s.tracer
+	if tracer == nil {
+		tracer = tracerFromContext(ctx)
+	}
+
+	if s.logger != nil {
+		ctx = withLogger(ctx, s.logger)
+	}
+
+	ctx = withStepIdentifier(withTracer(ctx, tracer), s.identifier)
+	if s.compensation {
+		return tracer.StartCompensation(ctx, s.identifier)
+	}
+	return tracer.StartStep(ctx, s.identifier)
+}
+
+// recordAttempt appends the attempt to the history of the Step. If the attempt will be retried,
+// it notifies the observers that the Step is retrying.
+func (s *step) recordAttempt(ctx context.Context, attempt Attempt, retrying bool) {
+	s.mutex.Lock()
+	s.attempts = append(s.attempts, attempt)
+	s.mutex.Unlock()
+
+	metricsFromContext(ctx).AttemptFinished(s.identifier, attempt, retrying)
+
+	if retrying {
+		logContext(ctx, slog.LevelWarn, "retrying step", slog.Int(LogKeyAttempt, attempt.Number),
+			slog.Duration("delay", attempt.NextDelay), slog.Any("error", attempt.Err))
+
+		notification := s.notification(ctx, Retrying)
+		notification.Attempt = &attempt
+		s.notfier.Notify(ctx, notification)
+	}
+}
+
+// resetAttempts clears the history of attempts and the output of the Step.
+func (s *step) resetAttempts() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.attempts = nil
+	s.output = nil
+}
+
+// setOutput replaces the output of the Step.
+func (s *step) setOutput(output []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.output = append([]byte(nil), output...)
+}
+
+// storeOf returns the IdempotencyStore of the Step or, if it has none, the one carried by the context.
+func (s *step) storeOf(ctx context.Context) IdempotencyStore {
+	if s.idempotencyStore != nil {
+		return s.idempotencyStore
+	}
+	store, _ := idempotencyStoreFromContext(ctx)
+	return store
 }
 
-// setStatus sets the status of the Step and notifies the observers that a notification occurred.
-func (s *step) setStatus(ctx context.Context, status Status) {
+// forget replaces the record of the Step in its IdempotencyStore, if any, with one that is not Successed, so
+// the Step runs again when it is triggered again. It is called once the effects of the Step were compensated.
+func (s *step) forget(ctx context.Context) error {
+	store := s.storeOf(ctx)
+	if store == nil {
+		return nil
+	}
+	saga, _ := sagaIdentifierFromContext(ctx)
+	record := IdempotencyRecord{Status: Undefined, CompletedAt: clockFromContext(ctx).Now()}
+	return store.Save(ctx, IdempotencyKey(saga, s.identifier), record)
+}
+
+// saveRecord stores the Step as succeeded, along with its output, in the IdempotencyStore. A record
+// that could not be saved is logged, since the action already succeeded.
+func (s *step) saveRecord(ctx context.Context, store IdempotencyStore, key string) {
+	record := IdempotencyRecord{Status: Successed, Output: s.Output(), CompletedAt: clockFromContext(ctx).Now()}
+	if err := store.Save(ctx, key, record); err != nil {
+		logContext(ctx, slog.LevelError, "idempotency record not saved", slog.Any("error", err))
+	}
+}
+
+// finish sets the status of the Step according to the error returned by its action and returns
+// the error that Run should return. A skipped Step returns no error. The status is notified by execute.
+func (s *step) finish(ctx context.Context, err error) error {
+	status, err := resultStatus(ctx, err)
+	s.mutex.Lock()
 	s.status = status
-	notification, _ := NewNotification(s.identifier, status)
-	s.notfier.Notify(ctx, notification)
+	s.mutex.Unlock()
+	if status == Skipped {
+		return nil
+	}
+	return err
+}
+
+// resultStatus returns the status of a Step whose action returned the given error, along with the error
+// stripped of the status given by the Retrier. A canceled context results in Canceled, whatever the error.
+func resultStatus(ctx context.Context, err error) (Status, error) {
+	if err == nil {
+		return Successed, nil
+	}
+
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status, statusErr.err
+	}
+
+	switch {
+	case ctx.Err() != nil, errors.Is(err, context.Canceled):
+		return Canceled, err
+	case errors.Is(err, ErrSkipped):
+		return Skipped, err
+	default:
+		return Failed, err
+	}
+}
+
+// getNotifier returns the notifier that will be used to notify
+func (s *step) getNotifier() Notifier {
+	return s.notfier
 }
 
 // setState sets the state of the Step and notifies the observers that a notification occurred.
 func (s *step) setState(ctx context.Context, state State) {
+	s.mutex.Lock()
 	s.state = state
-	notification, _ := NewNotification(s.identifier, state)
-	s.notfier.Notify(ctx, notification)
+	s.mutex.Unlock()
+	s.notfier.Notify(ctx, s.notification(ctx, state))
+}
+
+// notification returns the notification of the event of the Step, linked to the saga instance
+// that runs it and to the parent of that saga, if any.
+func (s *step) notification(ctx context.Context, event Event) Notification {
+	notification, _ := NewNotification(s.identifier, event)
+	notification.Saga, _ = sagaIdentifierFromContext(ctx)
+	notification.Parent, _ = parentSagaIdentifierFromContext(ctx)
+	return notification
+}
+
+// stepIdentifierKey is the context key of the identifier of the running Step.
+type stepIdentifierKey struct{}
+
+// withStepIdentifier returns a copy of the context that carries the identifier of the running Step.
+func withStepIdentifier(ctx context.Context, identifier Identifier) context.Context {
+	return context.WithValue(ctx, stepIdentifierKey{}, identifier)
+}
+
+// stepIdentifierFromContext returns the identifier of the running Step carried by the context, if any.
+func stepIdentifierFromContext(ctx context.Context) (Identifier, bool) {
+	identifier, ok := ctx.Value(stepIdentifierKey{}).(Identifier)
+	return identifier, ok
 }
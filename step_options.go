@@ -1,20 +1,49 @@
 package sagas
 
+import (
+	"log/slog"
+	"time"
+)
+
 type stepOptions struct {
-	Retrier  Retrier
-	Status   Status
-	State    State
-	Notifier Notifier
+	Identifier       Identifier
+	Retrier          Retrier
+	Status           Status
+	State            State
+	Notifier         Notifier
+	RateLimiter      RateLimiter
+	Tracer           Tracer
+	Compensation     bool
+	Logger           *slog.Logger
+	IdempotencyStore IdempotencyStore
+	ExecutionPolicy  ExecutionPolicy
+	NotBefore        time.Time
+	Delay            time.Duration
+	Scheduler        Scheduler
+	Clock            Clock
+	Middlewares      []ActionMiddleware
 }
 
 type StepOption func(*stepOptions)
 
 func newStepOptions(opts ...StepOption) stepOptions {
 	options := stepOptions{
-		Retrier:  nil,
-		Status:   Undefined,
-		State:    Idle,
-		Notifier: NewNotifier(),
+		Identifier:       nil,
+		Retrier:          nil,
+		Status:           Undefined,
+		State:            Idle,
+		Notifier:         NewNotifier(),
+		RateLimiter:      nil,
+		Tracer:           nil,
+		Compensation:     false,
+		Logger:           nil,
+		IdempotencyStore: nil,
+		ExecutionPolicy:  RunAlways,
+		NotBefore:        time.Time{},
+		Delay:            0,
+		Scheduler:        nil,
+		Clock:            nil,
+		Middlewares:      nil,
 	}
 
 	for _, opt := range opts {
@@ -24,6 +53,15 @@ func newStepOptions(opts ...StepOption) stepOptions {
 	return options
 }
 
+// WithStepIdentifier sets the identifier of the step, instead of a unique one made from its name. It is used
+// to give the step a stable identifier, known by the other services of a choreography or kept by a saga that is
+// resumed in another process, so the idempotency key of the step does not change.
+func WithStepIdentifier(identifier Identifier) StepOption {
+	return func(o *stepOptions) {
+		o.Identifier = identifier
+	}
+}
+
 func WithStepRetrier(retrier Retrier) StepOption {
 	return func(o *stepOptions) {
 		o.Retrier = retrier
@@ -47,3 +85,91 @@ func WithStepNotifier(notifier Notifier) StepOption {
 		o.Notifier = notifier
 	}
 }
+
+// WithStepRateLimiter sets the rate limiter acquired by the step before each attempt of its action, including
+// the retries of any retrier. If the limiter refuses an attempt, the step fails without retrying, with the
+// limiter error joined to the error of the last attempt.
+func WithStepRateLimiter(limiter RateLimiter) StepOption {
+	return func(o *stepOptions) {
+		o.RateLimiter = limiter
+	}
+}
+
+// WithStepTracer sets the tracer of the step. By default, the step uses the tracer of the saga.
+func WithStepTracer(tracer Tracer) StepOption {
+	return func(o *stepOptions) {
+		o.Tracer = tracer
+	}
+}
+
+// WithStepCompensation marks the step as a compensation, which undoes the work of other steps.
+// A compensation step is traced with the StartCompensation hook of the Tracer.
+func WithStepCompensation() StepOption {
+	return func(o *stepOptions) {
+		o.Compensation = true
+	}
+}
+
+// WithStepLogger sets the logger to the step, used instead of the logger of the saga for the log lines of the
+// step and its action.
+func WithStepLogger(logger *slog.Logger) StepOption {
+	return func(o *stepOptions) {
+		o.Logger = logger
+	}
+}
+
+// WithStepIdempotencyStore sets the store that keeps the results of the step, used instead of the store of the
+// saga. A step recorded as succeeded is not run again.
+func WithStepIdempotencyStore(store IdempotencyStore) StepOption {
+	return func(o *stepOptions) {
+		o.IdempotencyStore = store
+	}
+}
+
+// WithStepExecutionPolicy sets what the step does when it is triggered again, either while it is running or
+// after it completed. By default, the step runs every time it is triggered.
+func WithStepExecutionPolicy(policy ExecutionPolicy) StepOption {
+	return func(o *stepOptions) {
+		o.ExecutionPolicy = policy
+	}
+}
+
+// WithStepNotBefore sets the time before which the step does not run. A step triggered earlier is scheduled to
+// run at that time instead of blocking while it waits.
+func WithStepNotBefore(notBefore time.Time) StepOption {
+	return func(o *stepOptions) {
+		o.NotBefore = notBefore
+	}
+}
+
+// WithStepDelay sets the delay after which the step runs once it is triggered. The step is scheduled to run
+// after the delay instead of blocking while it waits.
+func WithStepDelay(delay time.Duration) StepOption {
+	return func(o *stepOptions) {
+		o.Delay = delay
+	}
+}
+
+// WithStepScheduler sets the scheduler of the delayed runs of the step, used instead of the scheduler of the
+// saga.
+func WithStepScheduler(scheduler Scheduler) StepOption {
+	return func(o *stepOptions) {
+		o.Scheduler = scheduler
+	}
+}
+
+// WithStepClock sets the clock used to time the step, its attempts and its delays, used instead of the clock of
+// the saga. The clock is also given to the retrier and the action of the step.
+func WithStepClock(clock Clock) StepOption {
+	return func(o *stepOptions) {
+		o.Clock = clock
+	}
+}
+
+// WithStepMiddleware adds middlewares that wrap every attempt of the step, inside the middlewares of the saga.
+// The first middleware is the outermost.
+func WithStepMiddleware(middlewares ...ActionMiddleware) StepOption {
+	return func(o *stepOptions) {
+		o.Middlewares = append(o.Middlewares, middlewares...)
+	}
+}
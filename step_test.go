@@ -3,7 +3,9 @@ package sagas
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -241,3 +243,287 @@ func Test_step_GetONotifier(t *testing.T) {
 		})
 	}
 }
+
+func Test_step_Run_WithRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		runs          int
+		expectedError string
+	}{
+		{
+			name: "[SUCCESS] Should run the step while the limiter allows it",
+			runs: 1,
+		},
+
+		{
+			name:          "[ERROR] Should fail the step when the limit is exceeded",
+			runs:          2,
+			expectedError: ErrRateLimited.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				s := NewStep(
+					"test",
+					makeActionNoError(context.Background()),
+					WithStepRateLimiter(NewRateLimiter(1, 1, RateLimitFailFast)),
+				)
+				var err error
+				for i := 0; i < test.runs; i++ {
+					err = s.Run(context.Background())
+				}
+
+				if test.expectedError == "" {
+					assert.NoError(t, err)
+					assert.Equal(t, Successed, s.GetStatus())
+				} else {
+					assert.Equal(t, test.expectedError, err.Error())
+					assert.Equal(t, Failed, s.GetStatus())
+				}
+			})
+		})
+	}
+}
+
+func Test_step_Run_WithRateLimiter_Retry(t *testing.T) {
+	t.Parallel()
+
+	errFailed := errors.New("error")
+
+	tests := []struct {
+		name      string
+		burst     int
+		retrier   Retrier
+		wantCalls int
+		// wantAttempts is the number of attempts recorded by the retrier.
+		wantAttempts int
+		wantErrs     []error
+	}{
+		{
+			name:         "[SUCCESS] Should acquire the limiter before each attempt",
+			burst:        3,
+			retrier:      NewRetrier(BackoffConstant(2, time.Millisecond)),
+			wantCalls:    3,
+			wantAttempts: 3,
+			wantErrs:     []error{errFailed},
+		},
+
+		{
+			name:         "[ERROR] Should fail the step with the last error when the limiter refuses a retry",
+			burst:        2,
+			retrier:      NewRetrier(BackoffConstant(2, time.Millisecond)),
+			wantCalls:    2,
+			wantAttempts: 2,
+			wantErrs:     []error{ErrRateLimited, errFailed},
+		},
+
+		{
+			name:      "[ERROR] Should acquire the limiter before each attempt of a custom retrier",
+			burst:     2,
+			retrier:   loopRetrier{attempts: 3},
+			wantCalls: 2,
+			wantErrs:  []error{ErrRateLimited, errFailed},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				calls := 0
+				s := NewStep("test", func(context.Context) error {
+					calls++
+					return errFailed
+				},
+					WithStepRetrier(test.retrier),
+					WithStepRateLimiter(NewRateLimiter(0.001, test.burst, RateLimitFailFast)),
+				)
+
+				err := s.Run(context.Background())
+
+				for _, want := range test.wantErrs {
+					assert.ErrorIs(t, err, want)
+				}
+				assert.Equal(t, test.wantCalls, calls)
+				assert.Len(t, s.Attempts(), test.wantAttempts)
+				assert.Equal(t, Failed, s.GetStatus())
+			})
+		})
+	}
+}
+
+// loopRetrier is a Retrier that runs the action until it succeeds, the attempts are exhausted or the context
+// is done, without recording the attempts.
+type loopRetrier struct {
+	attempts int
+}
+
+func (r loopRetrier) Retry(ctx context.Context, action Action) error {
+	var err error
+	for i := 0; i < r.attempts && ctx.Err() == nil; i++ {
+		if err = action.run(ctx); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func Test_step_Run_Outcomes(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		action  ActionFn
+		options []StepOption
+		ctx     func() (context.Context, context.CancelFunc)
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		wantStatus    Status
+		expectedError string
+	}{
+		{
+			name: "[SUCCESS] Should skip the step if the action returns ErrSkipped",
+			args: args{
+				action: func(context.Context) error { return ErrSkipped },
+				ctx:    func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			},
+			wantStatus: Skipped,
+		},
+
+		{
+			name: "[SUCCESS] Should skip the step if the classifier returns Skipped",
+			args: args{
+				action: makeActionError(context.Background()),
+				options: []StepOption{
+					WithStepRetrier(NewRetrier(
+						BackoffConstant(3, 1),
+						WithRetrierClassifier(ClassifierFunc(func(error) Status { return Skipped })),
+					)),
+				},
+				ctx: func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			},
+			wantStatus: Skipped,
+		},
+
+		{
+			name: "[ERROR] Should cancel the step if the classifier returns Canceled",
+			args: args{
+				action: makeActionError(context.Background()),
+				options: []StepOption{
+					WithStepRetrier(NewRetrier(
+						BackoffConstant(3, 1),
+						WithRetrierClassifier(ClassifierFunc(func(error) Status { return Canceled })),
+					)),
+				},
+				ctx: func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			},
+			wantStatus:    Canceled,
+			expectedError: "action failed",
+		},
+
+		{
+			name: "[ERROR] Should cancel the step if the context is canceled",
+			args: args{
+				action: func(ctx context.Context) error { return ctx.Err() },
+				ctx: func() (context.Context, context.CancelFunc) {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+					return ctx, cancel
+				},
+			},
+			wantStatus:    Canceled,
+			expectedError: "context canceled",
+		},
+
+		{
+			name: "[ERROR] Should cancel the step if the context is canceled while retrying",
+			args: args{
+				action: makeActionError(context.Background()),
+				options: []StepOption{
+					WithStepRetrier(NewRetrier(BackoffConstant(3, 1*time.Hour))),
+				},
+				ctx: func() (context.Context, context.CancelFunc) {
+					return context.WithTimeout(context.Background(), 10*time.Millisecond)
+				},
+			},
+			wantStatus:    Canceled,
+			expectedError: "context deadline exceeded",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				ctx, cancel := test.args.ctx()
+				defer cancel()
+				s := NewStep("test", test.args.action, test.args.options...)
+				err := s.Run(ctx)
+
+				if test.expectedError == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.Equal(t, test.expectedError, err.Error())
+				}
+				assert.Equal(t, test.wantStatus, s.GetStatus())
+				assert.Equal(t, Completed, s.GetState())
+			})
+		})
+	}
+}
+
+func Test_NewStep_WithIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		options []StepOption
+		want    string
+	}{
+		{
+			name:    "[SUCCESS] Should use the given identifier",
+			options: []StepOption{WithStepIdentifier(NewStableIdentifier("payments.charge"))},
+			want:    "payments.charge",
+		},
+
+		{
+			name:    "[SUCCESS] Should make a unique identifier from the name by default",
+			options: nil,
+			want:    "test:",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				got := NewStep("test", makeActionNoError(context.Background()), test.options...)
+				assert.True(t, strings.HasPrefix(got.GetIdentifier().String(), test.want))
+			})
+		})
+	}
+}
+
+func Test_NewStep_WithClock(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	s := NewStep("step", func(context.Context) error { return nil }, WithStepClock(clock))
+
+	assert.NoError(t, s.Run(context.Background()))
+
+	assert.Equal(t, NewIdentifierWithClock("step", clock), s.GetIdentifier())
+	assert.Equal(t, start, s.Attempts()[0].StartedAt)
+	assert.Equal(t, start, s.Attempts()[0].EndedAt)
+}
@@ -0,0 +1,148 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrSubSagaFailed is returned by a sub-saga step whose saga ended without succeeding.
+var ErrSubSagaFailed = errors.New("sub-saga failed")
+
+// SagaInstance is an instance of a reusable saga, built by a SagaDefinition.
+type SagaInstance struct {
+	// Saga is the saga to run.
+	Saga Saga
+	// Ender returns true once the saga ended, whether it succeeded or not.
+	Ender EnderFn
+	// Succeeded returns true if the saga that ended succeeded.
+	Succeeded func() bool
+	// Compensate undoes the effects of the saga once it succeeded. It is run when the parent saga
+	// compensates the sub-saga step.
+	Compensate ActionFn
+}
+
+// SagaDefinition builds a new instance of a reusable saga every time it is called. The options link the
+// instance to the saga that runs it, so they must be given to NewSaga.
+type SagaDefinition func(options ...SagaOption) SagaInstance
+
+// SubSagaStep is a Step that runs a saga built by a SagaDefinition as a single step of another saga.
+type SubSagaStep interface {
+	Step
+	// Compensation returns a new compensation step that compensates the last saga run by the step, if it
+	// succeeded. It is skipped otherwise.
+	Compensation(options ...StepOption) Step
+}
+
+// subSagaStep is the concrete implementation of the SubSagaStep interface.
+type subSagaStep struct {
+	*step
+	definition SagaDefinition
+	// last is the last instance run by the step.
+	last *SagaInstance
+	// runs is the number of instances run by the step.
+	runs  int
+	mutex sync.Mutex
+}
+
+// NewSubSagaStep creates a new Step that runs a new instance of the saga built by the definition every time it
+// runs. The step succeeds if the saga succeeds and fails with ErrSubSagaFailed otherwise. The saga is given an
+// identifier made from the identifiers of the parent saga and of the step and the number of the run, such as
+// "checkout/payment#2", so every run of the step has its own saga in the SagaStore while a process that resumes
+// the parent saga gives the same identifiers to the same runs. The notifications of its steps are linked to the
+// parent saga, and it shares the tracer, metrics recorder, logger, hooks, worker pool, middlewares and
+// idempotency store of the parent saga, unless the definition overrides them. The step lends its worker of the
+// shared worker pool to the saga while it runs. A panic will occur if the definition is nil or the name is empty.
+// Example:
+//
+//	payment := sagas.NewSubSagaStep("payment", func(options ...sagas.SagaOption) sagas.SagaInstance {
+//		saga := sagas.NewSaga(options...)
+//		saga.AddSteps(authorize, capture)
+//		...
+//		return sagas.SagaInstance{Saga: saga, Ender: ended, Succeeded: captured, Compensate: refund}
+//	})
+//
+//	checkout.When(reserve).Is(sagas.Successed).Then(sagas.NewAction(payment.Run)).Plan()
+//	checkout.When(ship).Is(sagas.Failed).Then(sagas.NewAction(payment.Compensation().Run)).Plan()
+//
+// The above example will run the payment saga as a step of the checkout saga, refunding the payment if the
+// shipment fails.
+func NewSubSagaStep(name string, definition SagaDefinition, options ...StepOption) SubSagaStep {
+	if definition == nil {
+		panic(errors.New("definition cannot be nil"))
+	}
+
+	s := &subSagaStep{
+		step:       NewStep(name, func(context.Context) error { return nil }, options...).(*step),
+		definition: definition,
+	}
+	s.action = NewAction(s.runSaga)
+	return s
+}
+
+// runSaga runs a new instance of the saga until it ends or the context is done.
+func (s *subSagaStep) runSaga(ctx context.Context) error {
+	s.mutex.Lock()
+	s.runs++
+	run := s.runs
+	s.mutex.Unlock()
+
+	options := []SagaOption{
+		WithSagaClock(clockFromContext(ctx)),
+		WithSagaTracer(tracerFromContext(ctx)),
+		WithSagaMetrics(metricsFromContext(ctx)),
+		WithSagaLogger(loggerFromContext(ctx)),
+	}
+	for _, hooks := range hooksFromContext(ctx) {
+		options = append(options, WithSagaHooks(hooks))
+	}
+	if pool, ok := workerPoolFromContext(ctx); ok {
+		options = append(options, WithSagaWorkerPool(pool))
+	}
+	if parent, ok := sagaIdentifierFromContext(ctx); ok {
+		child := NewStableIdentifier(fmt.Sprintf("%s/%s#%d", parent, s.identifier, run))
+		options = append(options, WithSagaIdentifier(child))
+	}
+	if p, ok := parkingFromContext(ctx); ok {
+		options = append(options, WithSagaStore(p.store))
+	}
+
+	instance := s.definition(options...)
+	s.mutex.Lock()
+	s.last = &instance
+	s.mutex.Unlock()
+
+	blocking(ctx, func() { instance.Saga.Run(ctx, instance.Ender) })
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !instance.Succeeded() {
+		return fmt.Errorf("%w: %s", ErrSubSagaFailed, instance.Saga.GetIdentifier())
+	}
+	return nil
+}
+
+// Compensation returns a new compensation step that compensates the last saga run by the step.
+func (s *subSagaStep) Compensation(options ...StepOption) Step {
+	name := IdentifierName(s.identifier) + " compensation"
+	options = append([]StepOption{WithStepCompensation()}, options...)
+	return NewStep(name, s.compensate, options...)
+}
+
+// compensate runs the compensation of the last saga run by the step, if it succeeded.
+func (s *subSagaStep) compensate(ctx context.Context) error {
+	s.mutex.Lock()
+	last := s.last
+	s.mutex.Unlock()
+
+	if last == nil || !last.Succeeded() {
+		return ErrSkipped
+	}
+
+	if last.Compensate == nil {
+		return fmt.Errorf("sub-saga %s has no compensation", last.Saga.GetIdentifier())
+	}
+	return last.Compensate(ctx)
+}
@@ -0,0 +1,210 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// paymentDefinition returns the definition of a payment saga whose capture returns the given error. The
+// notifications of its steps are sent to the given observer and its compensations are counted.
+func paymentDefinition(captureErr error, observer Observer, refunds *atomic.Int32) SagaDefinition {
+	return func(options ...SagaOption) SagaInstance {
+		authorize := NewStep("authorize", func(context.Context) error { return nil })
+		capture := NewStep("capture", func(context.Context) error { return captureErr })
+
+		saga := NewSaga(append(options, WithSagaObserver(observer))...)
+		saga.AddSteps(authorize, capture)
+		saga.When(authorize).Is(Successed).Then(NewAction(capture.Run)).Plan()
+
+		return SagaInstance{
+			Saga:      saga,
+			Ender:     func() bool { return capture.GetState() == Completed },
+			Succeeded: func() bool { return capture.GetStatus() == Successed },
+			Compensate: func(context.Context) error {
+				refunds.Add(1)
+				return nil
+			},
+		}
+	}
+}
+
+func Test_NewSubSagaStep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		captureErr       error
+		wantStatus       Status
+		wantErr          error
+		wantCompensation Status
+		wantRefunds      int32
+	}{
+		{
+			name:             "[SUCCESS] Should succeed when the sub-saga succeeds and compensate it",
+			wantStatus:       Successed,
+			wantCompensation: Successed,
+			wantRefunds:      1,
+		},
+
+		{
+			name:             "[FAILURE] Should fail when the sub-saga fails and skip its compensation",
+			captureErr:       errors.New("declined"),
+			wantStatus:       Failed,
+			wantErr:          ErrSubSagaFailed,
+			wantCompensation: Skipped,
+			wantRefunds:      0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var mutex sync.Mutex
+			var notifications []Notification
+			var refunds atomic.Int32
+			observer := NewObserverFunc(func(_ context.Context, n Notification) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				notifications = append(notifications, n)
+			})
+
+			payment := NewSubSagaStep("payment", paymentDefinition(test.captureErr, observer, &refunds))
+			compensation := payment.Compensation()
+			checkout := NewSaga(WithSagaName("checkout"))
+			checkout.AddSteps(payment, compensation)
+			checkout.When(payment).Is(Completed).Then(NewAction(compensation.Run)).Plan()
+			checkout.Run(context.Background(), func() bool { return compensation.GetState() == Completed })
+
+			assert.Equal(t, test.wantStatus, payment.GetStatus())
+			assert.Equal(t, test.wantCompensation, compensation.GetStatus())
+			assert.Equal(t, test.wantRefunds, refunds.Load())
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			child := checkout.GetIdentifier().String() + "/" + payment.GetIdentifier().String() + "#1"
+			assert.NotEmpty(t, notifications)
+			for _, n := range notifications {
+				assert.Equal(t, child, n.Saga.String())
+				assert.Equal(t, checkout.GetIdentifier(), n.Parent)
+			}
+		})
+	}
+}
+
+func Test_subSagaStep_Run(t *testing.T) {
+	t.Parallel()
+	var refunds atomic.Int32
+	observer := NewObserverFunc(func(context.Context, Notification) {})
+	payment := NewSubSagaStep("payment", paymentDefinition(errors.New("declined"), observer, &refunds))
+
+	err := payment.Run(context.Background())
+
+	assert.ErrorIs(t, err, ErrSubSagaFailed)
+	assert.Equal(t, Failed, payment.GetStatus())
+}
+
+func Test_NewSubSagaStep_Inherit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should share the tracer, metrics recorder and worker pool of the parent saga", func(t *testing.T) {
+		t.Parallel()
+		var refunds atomic.Int32
+		tracer := &recordingTracer{}
+		metrics := &queueMetrics{}
+		observer := NewObserverFunc(func(context.Context, Notification) {})
+
+		payment := NewSubSagaStep("payment", paymentDefinition(nil, observer, &refunds))
+		compensation := payment.Compensation()
+		checkout := NewSaga(WithSagaName("checkout"), WithSagaTracer(tracer), WithSagaMetrics(metrics),
+			WithSagaMaxConcurrency(2))
+		checkout.AddSteps(payment, compensation)
+		checkout.When(payment).Is(Completed).Then(NewAction(compensation.Run)).Plan()
+		checkout.Run(context.Background(), func() bool { return compensation.GetState() == Completed })
+
+		child := checkout.GetIdentifier().String() + "/" + payment.GetIdentifier().String() + "#1"
+		tracer.mutex.Lock()
+		var childSpan *recordedSpan
+		for _, span := range tracer.spans {
+			if span.kind == "saga" && span.name == child {
+				childSpan = span
+			}
+		}
+		tracer.mutex.Unlock()
+		if assert.NotNil(t, childSpan) {
+			assert.Equal(t, "attempt", childSpan.parent.kind)
+		}
+		assert.True(t, metrics.queued(checkout.GetIdentifier().String()))
+		assert.True(t, metrics.queued(child))
+	})
+}
+
+func Test_NewSubSagaStep_WorkerPool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should run the sub-saga in a worker pool of a single worker", func(t *testing.T) {
+		t.Parallel()
+		reserve := NewStep("reserve", func(context.Context) error { return nil })
+		payment := NewSubSagaStep("payment", func(options ...SagaOption) SagaInstance {
+			authorize := NewDelayStep("authorize", time.Millisecond)
+			capture := NewStep("capture", func(context.Context) error { return nil })
+			saga := NewSaga(options...)
+			saga.AddSteps(authorize, capture)
+			saga.When(authorize).Is(Successed).Then(NewAction(capture.Run)).Plan()
+			return SagaInstance{
+				Saga:      saga,
+				Ender:     func() bool { return capture.GetState() == Completed },
+				Succeeded: func() bool { return capture.GetStatus() == Successed },
+			}
+		})
+		checkout := NewSaga(WithSagaName("checkout"), WithSagaMaxConcurrency(1))
+		checkout.AddSteps(reserve, payment)
+		checkout.When(reserve).Is(Successed).Then(NewAction(payment.Run)).Plan()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		checkout.Run(ctx, func() bool { return payment.GetState() == Completed })
+
+		assert.NoError(t, ctx.Err())
+		assert.Equal(t, Successed, payment.GetStatus())
+	})
+}
+
+func Test_subSagaStep_Run_Twice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should give every run of the step its own saga", func(t *testing.T) {
+		t.Parallel()
+		var mutex sync.Mutex
+		sagas := make(map[string]bool)
+		var refunds atomic.Int32
+		observer := NewObserverFunc(func(_ context.Context, n Notification) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			sagas[n.Saga.String()] = true
+		})
+		store := NewMemorySagaStore()
+		parent := NewStableIdentifier("checkout")
+		ctx := withParking(withSagaIdentifier(context.Background(), parent), parking{store: store, hub: newSignalHub()})
+
+		payment := NewSubSagaStep("payment", paymentDefinition(nil, observer, &refunds),
+			WithStepIdentifier(NewStableIdentifier("payment")))
+		assert.NoError(t, payment.Run(ctx))
+		assert.NoError(t, payment.Run(ctx))
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		assert.Equal(t, map[string]bool{"checkout/payment#1": true, "checkout/payment#2": true}, sagas)
+	})
+}
+
+func Test_NewSubSagaStep_Panic(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() { NewSubSagaStep("payment", nil) })
+}
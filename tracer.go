@@ -0,0 +1,72 @@
+package sagas
+
+import "context"
+
+// Span is the interface that represents a unit of work traced by a Tracer.
+type Span interface {
+	// End ends the span. If the error is not nil, the span is recorded as failed.
+	End(err error)
+}
+
+// Tracer is the interface that wraps the hooks called to trace the execution of sagas, steps, attempts and
+// compensations. Each method starts a span as a child of the span carried by the context, and returns a copy of
+// the context carrying the new span. That context is the one given to the ActionFn, so the spans started by the
+// action are children of the span of the Step.
+type Tracer interface {
+	// StartSaga starts the span of a saga execution. It is called by Saga.Run.
+	StartSaga(ctx context.Context, saga Identifier) (context.Context, Span)
+	// StartStep starts the span of a step execution. It is called by Step.Run.
+	StartStep(ctx context.Context, step Identifier) (context.Context, Span)
+	// StartAttempt starts the span of a single attempt of a step. It is called for each attempt of the
+	// Step action, including the ones performed by the Retrier.
+	StartAttempt(ctx context.Context, step Identifier, attempt int) (context.Context, Span)
+	// StartCompensation starts the span of a compensation step execution. It is called by Step.Run instead of
+	// StartStep for the steps created with the WithStepCompensation option.
+	StartCompensation(ctx context.Context, step Identifier) (context.Context, Span)
+}
+
+// noopTracer is the Tracer used when no Tracer is provided. It does not trace anything.
+type noopTracer struct{}
+
+// noopSpan is the Span returned by the noopTracer.
+type noopSpan struct{}
+
+// StartSaga returns the given context and a span that does nothing.
+func (noopTracer) StartSaga(ctx context.Context, _ Identifier) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// StartStep returns the given context and a span that does nothing.
+func (noopTracer) StartStep(ctx context.Context, _ Identifier) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// StartAttempt returns the given context and a span that does nothing.
+func (noopTracer) StartAttempt(ctx context.Context, _ Identifier, _ int) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// StartCompensation returns the given context and a span that does nothing.
+func (noopTracer) StartCompensation(ctx context.Context, _ Identifier) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// End does nothing.
+func (noopSpan) End(error) {}
+
+// tracerKey is the context key of the Tracer.
+type tracerKey struct{}
+
+// withTracer returns a copy of the context that carries the given Tracer.
+func withTracer(ctx context.Context, tracer Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// tracerFromContext returns the Tracer carried by the context. If there is none, it returns a Tracer that
+// does not trace anything.
+func tracerFromContext(ctx context.Context) Tracer {
+	if tracer, ok := ctx.Value(tracerKey{}).(Tracer); ok {
+		return tracer
+	}
+	return noopTracer{}
+}
@@ -0,0 +1,207 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedSpan struct {
+	kind    string
+	name    string
+	attempt int
+	parent  *recordedSpan
+	err     error
+	ended   bool
+	mutex   *sync.Mutex
+}
+
+func (s *recordedSpan) End(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.err, s.ended = err, true
+}
+
+type recordedSpanKey struct{}
+
+type recordingTracer struct {
+	spans []*recordedSpan
+	mutex sync.Mutex
+}
+
+func (t *recordingTracer) start(ctx context.Context, kind string, id Identifier, attempt int) (context.Context, Span) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	parent, _ := ctx.Value(recordedSpanKey{}).(*recordedSpan)
+	span := &recordedSpan{kind: kind, name: id.String(), attempt: attempt, parent: parent, mutex: &t.mutex}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, recordedSpanKey{}, span), span
+}
+
+func (t *recordingTracer) StartSaga(ctx context.Context, saga Identifier) (context.Context, Span) {
+	return t.start(ctx, "saga", saga, 0)
+}
+
+func (t *recordingTracer) StartStep(ctx context.Context, step Identifier) (context.Context, Span) {
+	return t.start(ctx, "step", step, 0)
+}
+
+func (t *recordingTracer) StartAttempt(ctx context.Context, step Identifier, attempt int) (context.Context, Span) {
+	return t.start(ctx, "attempt", step, attempt)
+}
+
+func (t *recordingTracer) StartCompensation(ctx context.Context, step Identifier) (context.Context, Span) {
+	return t.start(ctx, "compensation", step, 0)
+}
+
+func (t *recordingTracer) kinds() []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	kinds := make([]string, 0, len(t.spans))
+	for _, span := range t.spans {
+		kinds = append(kinds, span.kind)
+	}
+	return kinds
+}
+
+func Test_tracerFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		tracer Tracer
+		want   Tracer
+	}{
+		{
+			name:   "[SUCCESS] Should return the tracer of the context",
+			tracer: &recordingTracer{},
+			want:   &recordingTracer{},
+		},
+
+		{
+			name:   "[SUCCESS] Should return a noop tracer if the context has none",
+			tracer: nil,
+			want:   noopTracer{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				ctx := context.Background()
+				if test.tracer != nil {
+					ctx = withTracer(ctx, test.tracer)
+				}
+				got := tracerFromContext(ctx)
+				assert.Equal(t, test.want, got)
+			})
+		})
+	}
+}
+
+func Test_step_Run_WithTracer(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		failures int
+		options  []StepOption
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantKinds []string
+	}{
+		{
+			name:      "[SUCCESS] Should trace the step and its attempt",
+			args:      args{},
+			wantKinds: []string{"step", "attempt"},
+		},
+
+		{
+			name: "[SUCCESS] Should trace every attempt of the retrier",
+			args: args{
+				failures: 1,
+				options:  []StepOption{WithStepRetrier(NewRetrier(BackoffConstant(1, 1*time.Millisecond)))},
+			},
+			wantKinds: []string{"step", "attempt", "attempt"},
+		},
+
+		{
+			name: "[SUCCESS] Should trace a compensation step",
+			args: args{
+				options: []StepOption{WithStepCompensation()},
+			},
+			wantKinds: []string{"compensation", "attempt"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				tracer := &recordingTracer{}
+				calls := 0
+				s := NewStep("test", func(ctx context.Context) error {
+					calls++
+					span, _ := ctx.Value(recordedSpanKey{}).(*recordedSpan)
+					assert.Equal(t, "attempt", span.kind)
+					if calls <= test.args.failures {
+						return assert.AnError
+					}
+					return nil
+				}, append(test.args.options, WithStepTracer(tracer))...)
+
+				err := s.Run(context.Background())
+
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantKinds, tracer.kinds())
+				for _, span := range tracer.spans[1:] {
+					assert.Equal(t, tracer.spans[0], span.parent)
+					assert.Equal(t, s.GetIdentifier().String(), span.name)
+					assert.True(t, span.ended)
+				}
+				assert.True(t, tracer.spans[0].ended)
+			})
+		})
+	}
+}
+
+func Test_saga_Run_WithTracer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		wantKinds []string
+	}{
+		{
+			name:      "[SUCCESS] Should trace the saga as the parent of its steps",
+			wantKinds: []string{"saga", "step", "attempt"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				tracer := &recordingTracer{}
+				starter := NewStep("starter", makeActionNoError(context.Background()))
+				c := NewSaga(WithSagaTracer(tracer))
+				c.AddSteps(starter)
+				c.Run(context.Background(), func() bool { return starter.GetState() == Completed })
+
+				assert.Equal(t, test.wantKinds, tracer.kinds())
+				assert.Equal(t, c.GetIdentifier().String(), tracer.spans[0].name)
+				assert.Equal(t, tracer.spans[0], tracer.spans[1].parent)
+				assert.Equal(t, tracer.spans[1], tracer.spans[2].parent)
+			})
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package sagas
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// transition is the Action that groups the actions planned for a notification with their options. The
+// execution plan dispatches its actions instead of running it as a single action.
+type transition struct {
+	actions []Action
+	// slots holds the actions of the transition that are running. It is nil if they are not limited.
+	slots       chan struct{}
+	sequential  bool
+	priority    int
+	stopOnError bool
+}
+
+// newTransition returns a new transition of the actions with the given options.
+func newTransition(actions []Action, options ...TransitionOption) *transition {
+	transitionOptions := newTransitionOptions(options...)
+
+	t := &transition{
+		actions:     actions,
+		sequential:  transitionOptions.Sequential,
+		priority:    transitionOptions.Priority,
+		stopOnError: transitionOptions.StopOnError,
+	}
+	if transitionOptions.MaxConcurrency > 0 {
+		t.slots = make(chan struct{}, transitionOptions.MaxConcurrency)
+	}
+	return t
+}
+
+// run runs the actions of the transition and waits for them. It is only called when the transition is run as a
+// single action, outside of an execution plan.
+func (t *transition) run(ctx context.Context) error {
+	if t.sequential {
+		return sequence{t}.run(ctx)
+	}
+
+	wg := sync.WaitGroup{}
+	t.dispatch(ctx, &wg)
+	blocking(ctx, wg.Wait)
+	return nil
+}
+
+// dispatch runs every action of the transition, once it has a free slot, without blocking the caller. The wait
+// group is done once every action ran or was skipped. If the transition stops on error, the actions that did
+// not start once an action failed are skipped.
+func (t *transition) dispatch(ctx context.Context, wg *sync.WaitGroup) {
+	var failed atomic.Bool
+	for _, a := range t.actions {
+		wg.Add(1)
+		if t.slots == nil {
+			dispatch(ctx, a, func(error) { wg.Done() })
+			continue
+		}
+
+		go func(a Action) {
+			select {
+			case t.slots <- struct{}{}:
+			case <-ctx.Done():
+				wg.Done()
+				return
+			}
+			if t.stopOnError && failed.Load() {
+				<-t.slots
+				wg.Done()
+				return
+			}
+			dispatch(ctx, a, func(err error) {
+				if err != nil {
+					failed.Store(true)
+				}
+				<-t.slots
+				wg.Done()
+			})
+		}(a)
+	}
+}
+
+// sequence is the Action that runs the sequential transitions planned for a notification one after another,
+// by priority and then in the order they were planned.
+type sequence []*transition
+
+// run runs the actions of every transition of the sequence in order. The errors of the actions are logged
+// with the logger carried by the context. It stops on the first error of a transition that stops on error.
+func (s sequence) run(ctx context.Context) error {
+	ordered := append(sequence(nil), s...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].priority > ordered[j].priority
+	})
+
+	for _, t := range ordered {
+		for _, a := range t.actions {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			err := a.run(ctx)
+			if err == nil {
+				continue
+			}
+			if t.stopOnError {
+				return err
+			}
+			logContext(ctx, slog.LevelError, "action failed", slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+// dispatch runs the action with the worker pool carried by the context or, if there is none, in a new
+// goroutine. The errors of the action are logged with the logger carried by the context. The done function,
+// if any, is called with the error of the action once it ran.
+func dispatch(ctx context.Context, a Action, done func(error)) {
+	run := func(ctx context.Context) {
+		err := a.run(ctx)
+		if err != nil {
+			logContext(ctx, slog.LevelError, "action failed", slog.Any("error", err))
+		}
+		if done != nil {
+			done(err)
+		}
+	}
+
+	if pool, ok := workerPoolFromContext(ctx); ok {
+		pool.Go(ctx, run)
+		return
+	}
+	go run(ctx)
+}
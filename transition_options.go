@@ -0,0 +1,63 @@
+package sagas
+
+type transitionOptions struct {
+	MaxConcurrency int
+	Sequential     bool
+	Priority       int
+	StopOnError    bool
+}
+
+type TransitionOption func(*transitionOptions)
+
+func newTransitionOptions(opts ...TransitionOption) transitionOptions {
+	options := transitionOptions{
+		MaxConcurrency: 0,
+		Sequential:     false,
+		Priority:       0,
+		StopOnError:    false,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// WithTransitionMaxConcurrency limits the number of actions of the transition that run at once, across every
+// notification that triggers it. By default, the actions are not limited, other than by the worker pool of the
+// saga.
+func WithTransitionMaxConcurrency(maximum int) TransitionOption {
+	return func(o *transitionOptions) {
+		o.MaxConcurrency = maximum
+	}
+}
+
+// WithTransitionSequential runs the actions of the transition one after another, in the order they were given
+// to Then. The sequential transitions planned for the same notification also run one after another, in the
+// order they were planned. By default, the actions run in parallel.
+func WithTransitionSequential() TransitionOption {
+	return func(o *transitionOptions) {
+		o.Sequential = true
+	}
+}
+
+// WithTransitionPriority runs the transition sequentially, before the sequential transitions planned for the
+// same notification with a lower priority. The transitions with the same priority run in the order they were
+// planned. By default, the priority is 0.
+func WithTransitionPriority(priority int) TransitionOption {
+	return func(o *transitionOptions) {
+		o.Sequential = true
+		o.Priority = priority
+	}
+}
+
+// WithTransitionStopOnError stops the transition on the first action that returns an error. The actions of the
+// transition that did not start yet are skipped and, if the transition is sequential, so are the sequential
+// transitions planned after it for the same notification. Parallel actions that are not limited by a maximum
+// concurrency all start at once, so none of them is skipped.
+func WithTransitionStopOnError() TransitionOption {
+	return func(o *transitionOptions) {
+		o.StopOnError = true
+	}
+}
@@ -0,0 +1,233 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newTransition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		options        []TransitionOption
+		wantSlots      int
+		wantSequential bool
+		wantPriority   int
+		wantStop       bool
+	}{
+		{
+			name:      "[SUCCESS] Should not limit the actions without options",
+			wantSlots: 0,
+		},
+		{
+			name:      "[SUCCESS] Should limit the actions to the maximum concurrency",
+			options:   []TransitionOption{WithTransitionMaxConcurrency(2)},
+			wantSlots: 2,
+		},
+		{
+			name:           "[SUCCESS] Should run the actions sequentially",
+			options:        []TransitionOption{WithTransitionSequential()},
+			wantSequential: true,
+		},
+		{
+			name:           "[SUCCESS] Should run the actions sequentially by priority",
+			options:        []TransitionOption{WithTransitionPriority(5), WithTransitionStopOnError()},
+			wantSequential: true,
+			wantPriority:   5,
+			wantStop:       true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := newTransition([]Action{NewAction(func(context.Context) error { return nil })}, test.options...)
+			assert.Len(t, got.actions, 1)
+			assert.Equal(t, test.wantSlots, cap(got.slots))
+			assert.Equal(t, test.wantSequential, got.sequential)
+			assert.Equal(t, test.wantPriority, got.priority)
+			assert.Equal(t, test.wantStop, got.stopOnError)
+		})
+	}
+}
+
+func Test_transition_dispatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		maximum int
+		pool    WorkerPool
+	}{
+		{
+			name:    "[SUCCESS] Should run at most the maximum concurrency of actions at once",
+			maximum: 2,
+		},
+		{
+			name:    "[SUCCESS] Should run the actions in the worker pool",
+			maximum: 3,
+			pool:    NewWorkerPool(1),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var running, peak int32
+			actions := make([]Action, 10)
+			for i := range actions {
+				actions[i] = NewAction(func(context.Context) error {
+					current := atomic.AddInt32(&running, 1)
+					for {
+						old := atomic.LoadInt32(&peak)
+						if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+					return nil
+				})
+			}
+
+			ctx := context.Background()
+			limit := int32(test.maximum)
+			if test.pool != nil {
+				ctx = withWorkerPool(ctx, test.pool)
+				limit = 1
+			}
+			wg := sync.WaitGroup{}
+			newTransition(actions, WithTransitionMaxConcurrency(test.maximum)).dispatch(ctx, &wg)
+			wg.Wait()
+
+			assert.LessOrEqual(t, atomic.LoadInt32(&peak), limit)
+		})
+	}
+}
+
+func Test_transition_dispatch_StopOnError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		options []TransitionOption
+		want    int32
+	}{
+		{
+			name:    "[SUCCESS] Should skip the actions that did not start after the first error",
+			options: []TransitionOption{WithTransitionMaxConcurrency(1), WithTransitionStopOnError()},
+			want:    1,
+		},
+		{
+			name:    "[SUCCESS] Should run every action if the transition does not stop on error",
+			options: []TransitionOption{WithTransitionMaxConcurrency(1)},
+			want:    5,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var ran int32
+			actions := make([]Action, 5)
+			for i := range actions {
+				actions[i] = NewAction(func(context.Context) error {
+					atomic.AddInt32(&ran, 1)
+					return errors.New("failed")
+				})
+			}
+
+			wg := sync.WaitGroup{}
+			newTransition(actions, test.options...).dispatch(context.Background(), &wg)
+			wg.Wait()
+
+			assert.Equal(t, test.want, atomic.LoadInt32(&ran))
+		})
+	}
+}
+
+func Test_sequence_run(t *testing.T) {
+	t.Parallel()
+
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name    string
+		plan    func(action func(name string, err error) Action) sequence
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "[SUCCESS] Should run the transitions in the order they were planned",
+			plan: func(action func(string, error) Action) sequence {
+				return sequence{
+					newTransition([]Action{action("a", nil), action("b", nil)}, WithTransitionSequential()),
+					newTransition([]Action{action("c", nil)}, WithTransitionSequential()),
+				}
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "[SUCCESS] Should run the transitions by priority and then in the order they were planned",
+			plan: func(action func(string, error) Action) sequence {
+				return sequence{
+					newTransition([]Action{action("a", nil)}, WithTransitionSequential()),
+					newTransition([]Action{action("b", nil)}, WithTransitionPriority(10)),
+					newTransition([]Action{action("c", nil)}, WithTransitionPriority(-1)),
+					newTransition([]Action{action("d", nil)}, WithTransitionPriority(10)),
+				}
+			},
+			want: []string{"b", "d", "a", "c"},
+		},
+		{
+			name: "[SUCCESS] Should run the next actions after an error if the transition does not stop on error",
+			plan: func(action func(string, error) Action) sequence {
+				return sequence{
+					newTransition([]Action{action("a", errFailed), action("b", nil)}, WithTransitionSequential()),
+				}
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "[FAILURE] Should stop the sequence on the first error of a transition that stops on error",
+			plan: func(action func(string, error) Action) sequence {
+				return sequence{
+					newTransition([]Action{action("a", errFailed), action("b", nil)},
+						WithTransitionSequential(), WithTransitionStopOnError()),
+					newTransition([]Action{action("c", nil)}, WithTransitionSequential()),
+				}
+			},
+			want:    []string{"a"},
+			wantErr: errFailed,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var ran []string
+			action := func(name string, err error) Action {
+				return NewAction(func(context.Context) error {
+					ran = append(ran, name)
+					return err
+				})
+			}
+
+			err := test.plan(action).run(context.Background())
+
+			assert.ErrorIs(t, err, test.wantErr)
+			assert.Equal(t, test.want, ran)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+)
+
+// TransportHandler is a function that handles the messages received from a Transport.
+type TransportHandler func(ctx context.Context, data []byte)
+
+// Transport is the interface that wraps the methods of a message bus used to exchange notifications between
+// services. The messages are serialized by a Codec.
+type Transport interface {
+	// Publish sends the message to the subscribers of the topic.
+	Publish(ctx context.Context, topic string, data []byte) error
+	// Subscribe registers the handler to receive the messages of the topic until the context is done. It does
+	// not block.
+	Subscribe(ctx context.Context, topic string, handler TransportHandler) error
+}
+
+// channelTransport is an in-memory implementation of the Transport interface backed by channels.
+type channelTransport struct {
+	subscriptions map[string][]*channelSubscription
+	buffer        int
+	mutex         sync.RWMutex
+}
+
+// channelSubscription is a subscription of a channelTransport. Its messages are handled in order by a
+// goroutine of its own.
+type channelSubscription struct {
+	messages chan []byte
+	done     <-chan struct{}
+}
+
+// NewChannelTransport returns a new Transport that delivers the messages in memory, through channels that
+// buffer up to buffer messages per subscription. It is meant for tests and for choreographies within a single
+// process. A panic will occur if the buffer is negative. Example:
+//
+//	transport := sagas.NewChannelTransport(16)
+//
+//	notifier := sagas.NewRemoteNotifier(transport, "orders")
+//
+// The above example will create a notifier that publishes the notifications in memory.
+func NewChannelTransport(buffer int) Transport {
+	if buffer < 0 {
+		panic("buffer can not be negative")
+	}
+
+	return &channelTransport{
+		subscriptions: make(map[string][]*channelSubscription),
+		buffer:        buffer,
+	}
+}
+
+// Publish sends the message to every subscription of the topic, waiting for room in their buffers. It returns
+// the error of the context if it is done before the message is sent.
+func (t *channelTransport) Publish(ctx context.Context, topic string, data []byte) error {
+	data = append([]byte(nil), data...)
+
+	t.mutex.RLock()
+	subscriptions := append([]*channelSubscription(nil), t.subscriptions[topic]...)
+	t.mutex.RUnlock()
+
+	for _, subscription := range subscriptions {
+		select {
+		case subscription.messages <- data:
+		case <-subscription.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Subscribe registers the handler and starts a goroutine that handles the messages of the topic until the
+// context is done.
+func (t *channelTransport) Subscribe(ctx context.Context, topic string, handler TransportHandler) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	subscription := &channelSubscription{
+		messages: make(chan []byte, t.buffer),
+		done:     ctx.Done(),
+	}
+
+	t.mutex.Lock()
+	t.subscriptions[topic] = append(t.subscriptions[topic], subscription)
+	t.mutex.Unlock()
+
+	go func() {
+		defer t.unsubscribe(topic, subscription)
+		for {
+			select {
+			case data := <-subscription.messages:
+				handler(ctx, data)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// unsubscribe removes the subscription of the topic.
+func (t *channelTransport) unsubscribe(topic string, subscription *channelSubscription) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	subscriptions := t.subscriptions[topic]
+	for i, s := range subscriptions {
+		if s == subscription {
+			t.subscriptions[topic] = append(subscriptions[:i:i], subscriptions[i+1:]...)
+			break
+		}
+	}
+}
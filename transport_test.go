@@ -0,0 +1,115 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type receivedMessages struct {
+	messages []string
+	mutex    sync.Mutex
+}
+
+func (r *receivedMessages) handle(_ context.Context, data []byte) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.messages = append(r.messages, string(data))
+}
+
+func (r *receivedMessages) received() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string{}, r.messages...)
+}
+
+func Test_channelTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		topic   string
+		publish []string
+		want    []string
+	}{
+		{
+			name:    "[SUCCESS] Should deliver the messages of the topic in order",
+			topic:   "orders",
+			publish: []string{"a", "b", "c"},
+			want:    []string{"a", "b", "c"},
+		},
+
+		{
+			name:    "[SUCCESS] Should not deliver the messages of other topics",
+			topic:   "payments",
+			publish: []string{"a"},
+			want:    []string{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				transport := NewChannelTransport(0)
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				first, second := &receivedMessages{}, &receivedMessages{}
+				assert.NoError(t, transport.Subscribe(ctx, "orders", first.handle))
+				assert.NoError(t, transport.Subscribe(ctx, "orders", second.handle))
+
+				for _, message := range test.publish {
+					assert.NoError(t, transport.Publish(ctx, test.topic, []byte(message)))
+				}
+
+				assert.Eventually(t, func() bool {
+					return len(first.received()) == len(test.want) && len(second.received()) == len(test.want)
+				}, time.Second, time.Millisecond)
+				assert.Equal(t, test.want, first.received())
+				assert.Equal(t, test.want, second.received())
+			})
+		})
+	}
+}
+
+func Test_channelTransport_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should stop delivering when the context of the subscription is done", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			transport := NewChannelTransport(0)
+			ctx, cancel := context.WithCancel(context.Background())
+			received := &receivedMessages{}
+			assert.NoError(t, transport.Subscribe(ctx, "orders", received.handle))
+
+			cancel()
+
+			assert.Eventually(t, func() bool {
+				ct := transport.(*channelTransport)
+				ct.mutex.RLock()
+				defer ct.mutex.RUnlock()
+				return len(ct.subscriptions["orders"]) == 0
+			}, time.Second, time.Millisecond)
+			assert.NoError(t, transport.Publish(context.Background(), "orders", []byte("a")))
+			assert.Error(t, transport.Subscribe(ctx, "orders", received.handle))
+			assert.Empty(t, received.received())
+		})
+	})
+}
+
+func Test_NewChannelTransport_Panics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[FAILURE] Should panic with a negative buffer", func(t *testing.T) {
+		t.Parallel()
+		assert.Panics(t, func() {
+			NewChannelTransport(-1)
+		})
+	})
+}
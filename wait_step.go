@@ -0,0 +1,115 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrWaitTimeout is returned by a wait step whose timeout expired before it received its signal.
+	ErrWaitTimeout = errors.New("wait step timed out")
+	// ErrNotInSaga is returned by a step that can only run within a saga when it is run on its own.
+	ErrNotInSaga = errors.New("step must run within a saga")
+)
+
+// waiter is the action of a wait step.
+type waiter struct {
+	step    *step
+	signal  string
+	timeout time.Duration
+}
+
+// NewWaitStep creates a new Step that parks the saga until a signal of the given name is sent with Saga.Signal
+// or the timeout expires. While it waits, its state is Waiting and its parked state is kept in the SagaStore of
+// the saga, so it can be resumed by another process with Saga.Resume. The payload of the signal becomes the
+// output of the step. If the timeout expires, the step fails with ErrWaitTimeout; a timeout that is not
+// positive waits indefinitely. A panic will occur if the name or the signal are empty. Example:
+//
+//	approval := sagas.NewWaitStep("approval", "approved", 72*time.Hour,
+//		sagas.WithStepIdentifier(sagas.NewStableIdentifier("approval")))
+//
+//	// later, from a webhook handler
+//	err := saga.Signal(ctx, "approved", []byte(`{"by":"alice"}`))
+//
+// The above example will create a step that waits up to three days for the approval of the order.
+func NewWaitStep(name string, signal string, timeout time.Duration, options ...StepOption) Step {
+	if signal == "" {
+		panic(errors.New("signal cannot be empty"))
+	}
+
+	s := NewStep(name, func(context.Context) error { return nil }, options...).(*step)
+	w := &waiter{step: s, signal: signal, timeout: timeout}
+	s.action = NewAction(w.wait)
+	return s
+}
+
+// wait parks the step and waits for its signal, its deadline or the end of the context. A step whose context
+// is done stays parked and Waiting, so it can be resumed.
+func (w *waiter) wait(ctx context.Context) error {
+	p, ok := parkingFromContext(ctx)
+	if !ok {
+		return ErrNotInSaga
+	}
+	saga, _ := sagaIdentifierFromContext(ctx)
+
+	deadline, err := w.deadline(ctx, p.store, saga)
+	if err != nil {
+		return err
+	}
+
+	parked := ParkedStep{Step: w.step.identifier, Signal: w.signal, Deadline: deadline}
+	if err := p.store.Park(ctx, saga, parked); err != nil {
+		return err
+	}
+	w.step.setState(ctx, Waiting)
+
+	var expired <-chan time.Time
+	if !deadline.IsZero() {
+		clock := clockFromContext(ctx)
+		timer := clock.NewTimer(deadline.Sub(clock.Now()))
+		defer timer.Stop()
+		expired = timer.C()
+	}
+
+	for {
+		wake := p.hub.channel(w.signal)
+		payload, ok, err := p.store.TakeSignal(ctx, saga, w.signal)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			SetOutput(ctx, payload)
+			return p.store.Unpark(ctx, saga, w.step.identifier)
+		}
+
+		select {
+		case <-wake:
+		case <-expired:
+			return errors.Join(ErrWaitTimeout, p.store.Unpark(ctx, saga, w.step.identifier))
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// deadline returns the deadline of the step. A step that was already parked, before the saga was resumed,
+// keeps its deadline.
+func (w *waiter) deadline(ctx context.Context, store SagaStore, saga Identifier) (time.Time, error) {
+	parked, err := store.Parked(ctx, saga)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, p := range parked {
+		if p.Step.String() == w.step.identifier.String() {
+			return p.Deadline, nil
+		}
+	}
+
+	if w.timeout <= 0 {
+		return time.Time{}, nil
+	}
+	return clockFromContext(ctx).Now().Add(w.timeout), nil
+}
@@ -0,0 +1,211 @@
+package sagas
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewWaitStep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		signalFirst bool
+		signal      bool
+		wantStatus  Status
+		wantOutput  []byte
+	}{
+		{
+			name:       "[SUCCESS] Should wait for the signal and keep its payload as output",
+			signal:     true,
+			wantStatus: Successed,
+			wantOutput: []byte("approved"),
+		},
+
+		{
+			name:        "[SUCCESS] Should take a signal sent before the step waits",
+			signalFirst: true,
+			signal:      true,
+			wantStatus:  Successed,
+			wantOutput:  []byte("approved"),
+		},
+
+		{
+			name:       "[FAILURE] Should fail when the timeout expires",
+			timeout:    10 * time.Millisecond,
+			signal:     false,
+			wantStatus: Failed,
+			wantOutput: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.NotPanics(t, func() {
+				store := NewMemorySagaStore()
+				var waiting atomic.Bool
+				wait := NewWaitStep("approval", "approval", test.timeout)
+				wait.getNotifier().Add(observerFunc(func(_ context.Context, n Notification) {
+					if n.Event == Waiting {
+						waiting.Store(true)
+					}
+				}))
+				c := NewSaga(WithSagaStore(store))
+				c.AddSteps(wait)
+
+				if test.signalFirst {
+					assert.NoError(t, c.Signal(context.Background(), "approval", []byte("approved")))
+				}
+
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					c.Run(context.Background(), func() bool { return wait.GetState() == Completed })
+				}()
+
+				if test.signal && !test.signalFirst {
+					assert.Eventually(t, waiting.Load, time.Second, time.Millisecond)
+					assert.Equal(t, Waiting, wait.GetState())
+					assert.NoError(t, c.Signal(context.Background(), "approval", []byte("approved")))
+				}
+				<-done
+
+				assert.True(t, waiting.Load())
+				assert.Equal(t, test.wantStatus, wait.GetStatus())
+				assert.Equal(t, test.wantOutput, wait.Output())
+				parked, _ := store.Parked(context.Background(), c.GetIdentifier())
+				assert.Empty(t, parked)
+			})
+		})
+	}
+}
+
+func Test_NewWaitStep_Canceled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should stay parked and not complete when the context is done", func(t *testing.T) {
+		t.Parallel()
+		store := NewMemorySagaStore()
+		var shipped atomic.Bool
+		wait := NewWaitStep("approval", "approval", 0)
+		ship := NewStep("ship", func(context.Context) error {
+			shipped.Store(true)
+			return nil
+		})
+		c := NewSaga(WithSagaStore(store))
+		c.AddSteps(wait, ship)
+		c.When(wait).Is(Completed).Then(NewAction(ship.Run)).Plan()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			c.Run(ctx, func() bool { return ship.GetState() == Completed })
+		}()
+
+		assert.Eventually(t, func() bool {
+			parked, _ := store.Parked(context.Background(), c.GetIdentifier())
+			return len(parked) == 1
+		}, time.Second, time.Millisecond)
+		cancel()
+		<-done
+
+		assert.Equal(t, Waiting, wait.GetState())
+		assert.False(t, shipped.Load())
+		parked, _ := store.Parked(context.Background(), c.GetIdentifier())
+		assert.Len(t, parked, 1)
+	})
+}
+
+func Test_NewWaitStep_NotInSaga(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[FAILURE] Should fail when run on its own", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			err := NewWaitStep("approval", "approval", 0).Run(context.Background())
+			assert.ErrorIs(t, err, ErrNotInSaga)
+		})
+	})
+
+	t.Run("[PANIC] Should panic if the signal is empty", func(t *testing.T) {
+		t.Parallel()
+		assert.Panics(t, func() {
+			NewWaitStep("approval", "", 0)
+		})
+	})
+}
+
+func Test_saga_Resume(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should resume a parked saga in a new instance", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			store := NewMemorySagaStore()
+			id := NewStableIdentifier("order-42")
+			build := func() (Saga, Step) {
+				wait := NewWaitStep("approval", "approval", time.Hour,
+					WithStepIdentifier(NewStableIdentifier("approval")))
+				c := NewSaga(WithSagaIdentifier(id), WithSagaStore(store))
+				c.AddSteps(wait)
+				return c, wait
+			}
+
+			first, firstWait := build()
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				assert.Eventually(t, func() bool { return firstWait.GetState() == Waiting }, time.Second, time.Millisecond)
+				cancel()
+			}()
+			first.Run(ctx, func() bool { return false })
+
+			parked, err := store.Parked(context.Background(), id)
+			assert.NoError(t, err)
+			if assert.Len(t, parked, 1) {
+				assert.Equal(t, "approval", parked[0].Step.String())
+				assert.Equal(t, "approval", parked[0].Signal)
+			}
+
+			second, secondWait := build()
+			assert.NoError(t, second.Signal(context.Background(), "approval", []byte("approved")))
+			err = second.Resume(context.Background(), func() bool { return secondWait.GetState() == Completed })
+
+			assert.NoError(t, err)
+			assert.Equal(t, Successed, secondWait.GetStatus())
+			assert.Equal(t, []byte("approved"), secondWait.Output())
+			parked, _ = store.Parked(context.Background(), id)
+			assert.Empty(t, parked)
+		})
+	})
+
+	t.Run("[FAILURE] Should not resume a parked step that is not a step of the saga", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			store := NewMemorySagaStore()
+			id := NewStableIdentifier("order-42")
+			_ = store.Park(context.Background(), id, ParkedStep{Step: NewStableIdentifier("unknown")})
+
+			c := NewSaga(WithSagaIdentifier(id), WithSagaStore(store))
+			c.AddSteps(NewStep("starter", makeActionNoError(context.Background())))
+
+			assert.Error(t, c.Resume(context.Background(), func() bool { return true }))
+		})
+	})
+}
+
+func Test_saga_Signal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[FAILURE] Should not send a signal without name", func(t *testing.T) {
+		t.Parallel()
+		assert.Error(t, NewSaga().Signal(context.Background(), "", nil))
+	})
+}
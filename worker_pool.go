@@ -0,0 +1,209 @@
+package sagas
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// WorkerPool is the interface that wraps the method to run the actions of the execution plans with a bounded
+// number of workers. A single WorkerPool can be shared by many saga instances, capping the actions that run at
+// once across all of them.
+type WorkerPool interface {
+	// Go queues the function to run with the context once a worker is free. It does not block the caller.
+	Go(ctx context.Context, fn func(context.Context))
+}
+
+// workerPool is the concrete implementation of the WorkerPool interface. Its workers are started when there
+// are queued functions and stop when the queue is empty.
+type workerPool struct {
+	size int
+	// workers is the number of workers that hold a slot of the pool.
+	workers int
+	// waiting is the number of blocked functions that wait to take their slot back.
+	waiting int
+	queue   []poolTask
+	mutex   sync.Mutex
+	// freed is signaled when a slot of the pool is freed. It is created on first use.
+	freed *sync.Cond
+}
+
+// poolTask is a function queued in the worker pool.
+type poolTask struct {
+	ctx      context.Context
+	fn       func(context.Context)
+	queuedAt time.Time
+}
+
+// NewWorkerPool returns a new WorkerPool that runs at most size functions at once. The queue of the pool is not
+// bounded, so an action that triggers other actions never waits for them to be queued, and an action that waits
+// for the actions it triggers or for a sub-saga lends its worker to the pool while it waits, so they can run
+// whatever the size of the pool. An action that waits for a signal, such as a wait step, keeps its worker while
+// it waits. A panic will occur if the size is not positive. Example:
+//
+//	pool := sagas.NewWorkerPool(32)
+//
+//	checkout := sagas.NewSaga(sagas.WithSagaWorkerPool(pool))
+//	refund := sagas.NewSaga(sagas.WithSagaWorkerPool(pool))
+//
+// The above example will create two sagas that run at most 32 actions at once between them.
+func NewWorkerPool(size int) WorkerPool {
+	if size <= 0 {
+		panic(errors.New("size must be positive"))
+	}
+
+	return &workerPool{
+		size: size,
+	}
+}
+
+// Go queues the function and starts a worker if there are less workers than the size of the pool.
+func (p *workerPool) Go(ctx context.Context, fn func(context.Context)) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.queue = append(p.queue, poolTask{ctx: ctx, fn: fn, queuedAt: clockFromContext(ctx).Now()})
+	p.spawn()
+}
+
+// spawn starts a worker if there are queued functions and a free slot that no blocked function waits for. The
+// mutex must be held by the caller.
+func (p *workerPool) spawn() {
+	if len(p.queue) > 0 && p.waiting == 0 && p.workers < p.size {
+		p.workers++
+		go p.work()
+	}
+}
+
+// free gives a slot back to the pool, to a blocked function that waits for it or to a new worker. The mutex
+// must be held by the caller.
+func (p *workerPool) free() {
+	p.workers--
+	if p.freed != nil {
+		p.freed.Broadcast()
+	}
+	p.spawn()
+}
+
+// work runs the queued functions until the queue is empty or a blocked function waits for its slot back. The
+// time each function waited in the queue is recorded by the metrics recorder carried by its context.
+func (p *workerPool) work() {
+	for {
+		p.mutex.Lock()
+		if len(p.queue) == 0 || p.waiting > 0 {
+			p.free()
+			p.mutex.Unlock()
+			return
+		}
+		task := p.queue[0]
+		p.queue[0] = poolTask{}
+		p.queue = p.queue[1:]
+		p.mutex.Unlock()
+
+		if metrics, ok := metricsFromContext(task.ctx).(QueueMetricsRecorder); ok {
+			saga, _ := sagaIdentifierFromContext(task.ctx)
+			metrics.ActionQueued(saga, clockFromContext(task.ctx).Now().Sub(task.queuedAt))
+		}
+		slot := &poolSlot{pool: p, held: true}
+		task.fn(withPoolSlot(task.ctx, slot))
+
+		p.mutex.Lock()
+		slot.done = true
+		held := slot.held
+		if !held && p.freed != nil {
+			p.freed.Broadcast()
+		}
+		p.mutex.Unlock()
+		if !held {
+			return
+		}
+	}
+}
+
+// poolSlot is the slot of the pool held by the function that a worker runs.
+type poolSlot struct {
+	pool *workerPool
+	// held indicates whether the function holds the slot, blocked is the number of its goroutines that block,
+	// during which the slot is lent to the pool, and done indicates whether the function returned. They are
+	// guarded by the mutex of the pool.
+	held    bool
+	blocked int
+	done    bool
+}
+
+// release lends the slot to the pool, if it is not lent already. It reports whether the slot must be taken
+// back with reacquire, which is not the case once the function returned.
+func (s *poolSlot) release() bool {
+	p := s.pool
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if s.done {
+		return false
+	}
+	s.blocked++
+	if s.held {
+		s.held = false
+		p.free()
+	}
+	return true
+}
+
+// reacquire takes the slot back once no goroutine of the function blocks anymore, waiting for a free slot.
+func (s *poolSlot) reacquire() {
+	p := s.pool
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	s.blocked--
+	if s.done {
+		return
+	}
+	if p.freed == nil {
+		p.freed = sync.NewCond(&p.mutex)
+	}
+	p.waiting++
+	for s.blocked == 0 && !s.held && !s.done && p.workers >= p.size {
+		p.freed.Wait()
+	}
+	p.waiting--
+	if s.blocked == 0 && !s.held && !s.done {
+		s.held = true
+		p.workers++
+	}
+	p.spawn()
+}
+
+// poolSlotKey is the context key of the slot of the pool held by the running function.
+type poolSlotKey struct{}
+
+// withPoolSlot returns a copy of the context that carries the slot of the pool held by the running function. A nil
+// slot removes the one carried by the parent context.
+func withPoolSlot(ctx context.Context, slot *poolSlot) context.Context {
+	return context.WithValue(ctx, poolSlotKey{}, slot)
+}
+
+// blocking calls the function, which blocks until other actions end. If the caller runs in a worker of a
+// WorkerPool, the worker is lent to the pool until the function returns, so the actions it waits for can run.
+func blocking(ctx context.Context, fn func()) {
+	if slot, ok := ctx.Value(poolSlotKey{}).(*poolSlot); ok && slot != nil && slot.release() {
+		defer slot.reacquire()
+	}
+	fn()
+}
+
+// workerPoolKey is the context key of the WorkerPool of the saga.
+type workerPoolKey struct{}
+
+// withWorkerPool returns a copy of the context that carries the given WorkerPool. A nil WorkerPool removes the
+// one carried by the parent context.
+func withWorkerPool(ctx context.Context, pool WorkerPool) context.Context {
+	return context.WithValue(ctx, workerPoolKey{}, pool)
+}
+
+// workerPoolFromContext returns the WorkerPool carried by the context, if any.
+func workerPoolFromContext(ctx context.Context) (WorkerPool, bool) {
+	pool, ok := ctx.Value(workerPoolKey{}).(WorkerPool)
+	return pool, ok && pool != nil
+}
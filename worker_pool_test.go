@@ -0,0 +1,231 @@
+package sagas
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewWorkerPool(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		size      int
+		wantPanic bool
+	}{
+		{
+			name: "[SUCCESS] Should return a new WorkerPool",
+			size: 2,
+		},
+		{
+			name:      "[PANIC] Should panic if the size is zero",
+			size:      0,
+			wantPanic: true,
+		},
+		{
+			name:      "[PANIC] Should panic if the size is negative",
+			size:      -1,
+			wantPanic: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if test.wantPanic {
+				assert.Panics(t, func() { NewWorkerPool(test.size) })
+				return
+			}
+			assert.NotPanics(t, func() {
+				assert.Equal(t, &workerPool{size: test.size}, NewWorkerPool(test.size))
+			})
+		})
+	}
+}
+
+func Test_workerPool_Go(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		size  int
+		tasks int
+	}{
+		{
+			name:  "[SUCCESS] Should run one function at a time",
+			size:  1,
+			tasks: 5,
+		},
+		{
+			name:  "[SUCCESS] Should run at most the size of the pool at once",
+			size:  3,
+			tasks: 20,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			pool := NewWorkerPool(test.size)
+
+			var running, peak, ran int32
+			wg := sync.WaitGroup{}
+			for i := 0; i < test.tasks; i++ {
+				wg.Add(1)
+				pool.Go(context.Background(), func(context.Context) {
+					defer wg.Done()
+					current := atomic.AddInt32(&running, 1)
+					for {
+						old := atomic.LoadInt32(&peak)
+						if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+					atomic.AddInt32(&ran, 1)
+				})
+			}
+			wg.Wait()
+
+			assert.Equal(t, int32(test.tasks), atomic.LoadInt32(&ran))
+			assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(test.size))
+		})
+	}
+}
+
+func Test_workerPool_Go_QueueDelay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("[SUCCESS] Should record the time the functions waited for a worker", func(t *testing.T) {
+		t.Parallel()
+		metrics := &queueMetrics{}
+		saga := NewIdentifier("checkout")
+		ctx := withSagaIdentifier(withMetrics(context.Background(), metrics), saga)
+		pool := NewWorkerPool(1)
+
+		release := make(chan struct{})
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		pool.Go(ctx, func(context.Context) {
+			defer wg.Done()
+			<-release
+		})
+		pool.Go(ctx, func(context.Context) { wg.Done() })
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		delays := metrics.get()
+		assert.Len(t, delays, 2)
+		assert.GreaterOrEqual(t, delays[1], 20*time.Millisecond)
+	})
+}
+
+func Test_blocking(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		size   int
+		nested int
+	}{
+		{
+			name:   "[SUCCESS] Should lend the worker of a pool of a single worker while the function blocks",
+			size:   1,
+			nested: 1,
+		},
+		{
+			name:   "[SUCCESS] Should lend the workers while many functions block on nested functions",
+			size:   2,
+			nested: 4,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			pool := NewWorkerPool(test.size)
+
+			var running, peak int32
+			track := func() func() {
+				current := atomic.AddInt32(&running, 1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+						break
+					}
+				}
+				return func() { atomic.AddInt32(&running, -1) }
+			}
+
+			done := make(chan struct{})
+			outer := sync.WaitGroup{}
+			for i := 0; i < test.nested; i++ {
+				outer.Add(1)
+				pool.Go(context.Background(), func(ctx context.Context) {
+					defer outer.Done()
+					inner := sync.WaitGroup{}
+					inner.Add(2)
+					for j := 0; j < 2; j++ {
+						pool.Go(ctx, func(context.Context) {
+							defer inner.Done()
+							defer track()()
+							time.Sleep(time.Millisecond)
+						})
+					}
+					blocking(ctx, inner.Wait)
+				})
+			}
+			go func() {
+				outer.Wait()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				assert.Fail(t, "the pool deadlocked")
+			}
+			assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(test.size))
+		})
+	}
+}
+
+// queueMetrics is a MetricsRecorder that records the queueing delays of the actions.
+type queueMetrics struct {
+	noopMetrics
+	delays []time.Duration
+	sagas  map[string]bool
+	mutex  sync.Mutex
+}
+
+func (m *queueMetrics) ActionQueued(saga Identifier, delay time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.delays = append(m.delays, delay)
+	if m.sagas == nil {
+		m.sagas = make(map[string]bool)
+	}
+	m.sagas[identifierString(saga)] = true
+}
+
+func (m *queueMetrics) queued(saga string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.sagas[saga]
+}
+
+func (m *queueMetrics) get() []time.Duration {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]time.Duration(nil), m.delays...)
+}